@@ -2,30 +2,46 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 
 	pb "github.com/serhii12/grpc-go/blog-app/blogpb"
-	"google.golang.org/grpc"
+	"github.com/serhii12/grpc-go/client"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
 	address = "localhost:50051"
 )
 
+var (
+	tenant       = flag.String("tenant", "", "tenant ID sent in the x-tenant-id metadata, empty for the client certificate's or the server's default tenant")
+	clientConfig client.Config
+)
+
 func main() {
+	clientConfig.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
 	fmt.Println("Blog Client")
 
 	// Set up a connection to the server.
-	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock())
+	conn, err := client.Dial("blog-client", address, clientConfig)
 	if err != nil {
 		log.Fatalf("Could not connect: %v", err)
 	}
-
 	defer conn.Close()
 
-	c := pb.NewBlogServiceClient(conn)
+	c := pb.NewBlogServiceClient(conn.ClientConn)
+
+	// every request is scoped to the tenant named in the metadata, or else to
+	// the one of the client certificate or the server default
+	ctx := context.Background()
+	if *tenant != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-tenant-id", *tenant)
+	}
 
 	// --- Create Blog START ---
 	fmt.Println("Creating Blog")
@@ -36,7 +52,7 @@ func main() {
 		Content:  "Content of the first blog",
 	}
 
-	resp, err := c.CreateBlog(context.Background(), &pb.CreateBlogRequest{Blog: blog})
+	resp, err := c.CreateBlog(ctx, &pb.CreateBlogRequest{Blog: blog})
 	if err != nil {
 		log.Fatalf("Could create a blog: %v\n", err)
 	}
@@ -47,7 +63,7 @@ func main() {
 	// --- Read Blog START ---
 	fmt.Println("Reading Blog")
 
-	readResp, err := c.ReadBlog(context.Background(), &pb.ReadBlogRequest{BlogId: resp.GetBlog().GetId()})
+	readResp, err := c.ReadBlog(ctx, &pb.ReadBlogRequest{BlogId: resp.GetBlog().GetId()})
 	if err != nil {
 		log.Fatalf("Could read a blog: %v\n", err)
 	}
@@ -65,7 +81,7 @@ func main() {
 		Content:  "Content of the first blog, with some awesome additions!",
 	}
 
-	updateRes, err := c.UpdateBlog(context.Background(), &pb.UpdateBlogRequest{Blog: newBlog})
+	updateRes, err := c.UpdateBlog(ctx, &pb.UpdateBlogRequest{Blog: newBlog})
 	if err != nil {
 		fmt.Printf("Error happened while updating: %v \n", err)
 	}
@@ -74,7 +90,7 @@ func main() {
 	// --- Update Blog FINISHED ---
 
 	// --- Delete Blog START ---
-	deleteRes, err := c.DeleteBlog(context.Background(), &pb.DeleteBlogRequest{BlogId: resp.GetBlog().GetId()})
+	deleteRes, err := c.DeleteBlog(ctx, &pb.DeleteBlogRequest{BlogId: resp.GetBlog().GetId()})
 	if err != nil {
 		fmt.Printf("Error happened while deleting: %v \n", err)
 	}
@@ -83,7 +99,7 @@ func main() {
 	// --- Delete Blog FINISHED ---
 
 	// --- List Blog START ---
-	stream, err := c.ListBlog(context.Background(), &pb.ListBlogRequest{})
+	stream, err := c.ListBlog(ctx, &pb.ListBlogRequest{})
 	if err != nil {
 		log.Fatalf("error while calling ListBlog RPC: %v", err)
 	}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// fakeMongo keeps collections in memory. Its filters only support the
+// equality and $exists conditions and its updates only $set, which is all
+// the blog service uses.
+type fakeMongo struct {
+	mu          sync.Mutex
+	collections map[string]*fakeCollection
+}
+
+// newTestStore returns a tenant store over in-memory collections
+func newTestStore(cfg *serverConfig) (*tenantStore, *fakeMongo) {
+	fm := &fakeMongo{collections: make(map[string]*fakeCollection)}
+	store := newTenantStore(nil, cfg)
+	store.open = func(database, coll string) collection {
+		return fm.collection(database, coll)
+	}
+
+	return store, fm
+}
+
+// collection returns the named collection, creating it if needed
+func (fm *fakeMongo) collection(database, coll string) *fakeCollection {
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	name := database + "." + coll
+	if fm.collections[name] == nil {
+		fm.collections[name] = &fakeCollection{}
+	}
+
+	return fm.collections[name]
+}
+
+type fakeCollection struct {
+	mu   sync.Mutex
+	docs []bson.M
+}
+
+// toM converts a document or filter to a bson.M, so both compare alike
+func toM(v interface{}) bson.M {
+	data, err := bson.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	m := bson.M{}
+	if err := bson.Unmarshal(data, &m); err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func matches(doc, filter bson.M) bool {
+	for key, want := range filter {
+		got, ok := doc[key]
+		if cond, isCond := want.(bson.M); isCond {
+			if exists, hasExists := cond["$exists"]; hasExists && ok != exists.(bool) {
+				return false
+			}
+			continue
+		}
+		if !ok || !reflect.DeepEqual(got, want) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// find returns the documents matching filter
+func (fc *fakeCollection) find(filter interface{}) []interface{} {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	var found []interface{}
+	f := toM(filter)
+	for _, doc := range fc.docs {
+		if matches(doc, f) {
+			copied := bson.M{}
+			for key, value := range doc {
+				copied[key] = value
+			}
+			found = append(found, copied)
+		}
+	}
+
+	return found
+}
+
+func (fc *fakeCollection) InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	doc := toM(document)
+	if _, ok := doc["_id"]; !ok {
+		doc["_id"] = primitive.NewObjectID()
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.docs = append(fc.docs, doc)
+
+	return &mongo.InsertOneResult{InsertedID: doc["_id"]}, nil
+}
+
+func (fc *fakeCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	if err := ctx.Err(); err != nil {
+		return mongo.NewSingleResultFromDocument(bson.D{}, err, nil)
+	}
+
+	found := fc.find(filter)
+	if len(found) == 0 {
+		return mongo.NewSingleResultFromDocument(bson.D{}, mongo.ErrNoDocuments, nil)
+	}
+
+	return mongo.NewSingleResultFromDocument(found[0], nil, nil)
+}
+
+func (fc *fakeCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return mongo.NewCursorFromDocuments(fc.find(filter), nil, nil)
+}
+
+func (fc *fakeCollection) update(ctx context.Context, filter, update interface{}, many bool) (*mongo.UpdateResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	result := &mongo.UpdateResult{}
+	f, set := toM(filter), toM(update)["$set"].(bson.M)
+	for _, doc := range fc.docs {
+		if !matches(doc, f) {
+			continue
+		}
+		for key, value := range set {
+			doc[key] = value
+		}
+		result.MatchedCount++
+		result.ModifiedCount++
+		if !many {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func (fc *fakeCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return fc.update(ctx, filter, update, false)
+}
+
+func (fc *fakeCollection) UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return fc.update(ctx, filter, update, true)
+}
+
+func (fc *fakeCollection) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	f := toM(filter)
+	for i, doc := range fc.docs {
+		if matches(doc, f) {
+			fc.docs = append(fc.docs[:i], fc.docs[i+1:]...)
+			return &mongo.DeleteResult{DeletedCount: 1}, nil
+		}
+	}
+
+	return &mongo.DeleteResult{}, nil
+}
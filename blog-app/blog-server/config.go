@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/serhii12/grpc-go/config"
+)
+
+type mongoConfig struct {
+	URI        string `yaml:"uri" secret:"true" usage:"MongoDB connection URI"`
+	Database   string `yaml:"database" usage:"database for tenants without their own"`
+	Collection string `yaml:"collection" usage:"collection for tenants without their own"`
+
+	PingInterval time.Duration `yaml:"ping_interval" usage:"how often MongoDB is pinged for health checks"`
+	PingTimeout  time.Duration `yaml:"ping_timeout" usage:"how long a health check ping may take"`
+}
+
+// serverConfig is the blog-server configuration, see package config
+type serverConfig struct {
+	Server    config.Server           `yaml:"server"`
+	Log       config.Log              `yaml:"log"`
+	Metrics   config.Metrics          `yaml:"metrics"`
+	Tracing   config.Tracing          `yaml:"tracing"`
+	RateLimit config.RateLimit        `yaml:"rate_limit"`
+	Deadlines config.Deadlines        `yaml:"deadlines"`
+	Mongo     mongoConfig             `yaml:"mongo"`
+	Tenancy   tenancyConfig           `yaml:"tenancy"`
+	Tenants   map[string]tenantConfig `yaml:"tenants"`
+}
+
+func defaultConfig() *serverConfig {
+	return &serverConfig{
+		Server:    config.DefaultServer(),
+		Log:       config.DefaultLog(),
+		Metrics:   config.DefaultMetrics(),
+		Tracing:   config.DefaultTracing(),
+		RateLimit: config.DefaultRateLimit(),
+		Deadlines: config.DefaultDeadlines(),
+		Mongo: mongoConfig{
+			URI:        "mongodb://localhost:27017",
+			Database:   "mydb",
+			Collection: "blog",
+
+			PingInterval: 5 * time.Second,
+			PingTimeout:  2 * time.Second,
+		},
+		// without certificates or metadata every request belongs to one
+		// tenant, which also owns the blogs stored before tenants existed
+		Tenancy: tenancyConfig{DefaultTenant: "default", LegacyTenant: "default"},
+	}
+}
+
+func (c *serverConfig) Validate() error {
+	if err := c.Server.Validate(); err != nil {
+		return err
+	}
+	if err := c.Log.Validate(); err != nil {
+		return err
+	}
+	if err := c.Metrics.Validate(); err != nil {
+		return err
+	}
+	if err := c.Tracing.Validate(); err != nil {
+		return err
+	}
+	if err := c.RateLimit.Validate(); err != nil {
+		return err
+	}
+	if err := c.Deadlines.Validate(); err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(c.Mongo.URI, "mongodb://") && !strings.HasPrefix(c.Mongo.URI, "mongodb+srv://") {
+		return fmt.Errorf("mongo.uri: must start with mongodb:// or mongodb+srv://")
+	}
+	if c.Mongo.Database == "" {
+		return fmt.Errorf("mongo.database: must not be empty")
+	}
+	if c.Mongo.Collection == "" {
+		return fmt.Errorf("mongo.collection: must not be empty")
+	}
+	if c.Mongo.PingInterval <= 0 || c.Mongo.PingTimeout <= 0 {
+		return fmt.Errorf("mongo.ping_interval and mongo.ping_timeout must be positive")
+	}
+
+	if !c.Server.TLS.ClientAuth && !c.Tenancy.AllowMetadata && c.Tenancy.DefaultTenant == "" {
+		return fmt.Errorf("tenancy.default_tenant: must be set unless tenancy.allow_metadata or server.tls.client_auth is, or no request has a tenant")
+	}
+	if cfg, ok := c.Tenants[c.Tenancy.LegacyTenant]; ok && (cfg.Database != "" || cfg.Collection != "") {
+		return fmt.Errorf("tenancy.legacy_tenant: must use the shared collection, where legacy blogs are migrated in place")
+	}
+
+	for id := range c.Tenants {
+		if strings.TrimSpace(id) == "" {
+			return fmt.Errorf("tenants: tenant ID must not be empty")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultConfigIsValid(t *testing.T) {
+	if err := defaultConfig().Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
+
+func TestValidateRequiresATenant(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Tenancy.DefaultTenant = ""
+	if err := cfg.Validate(); err == nil || !strings.HasPrefix(err.Error(), "tenancy.default_tenant") {
+		t.Fatalf("got %v, want a tenancy.default_tenant error", err)
+	}
+
+	cfg.Tenancy.AllowMetadata = true
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("with tenancy.allow_metadata: %v", err)
+	}
+
+	cfg.Tenancy.AllowMetadata = false
+	cfg.Server.TLS.ClientAuth = true
+	cfg.Server.TLS.Enabled = true
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("with server.tls.client_auth: %v", err)
+	}
+}
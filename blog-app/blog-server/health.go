@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// serviceName is the fully qualified name health checks ask about
+const serviceName = "blog.BlogService"
+
+// pinger is the part of *mongo.Client health checks use
+type pinger interface {
+	Ping(ctx context.Context, rp *readpref.ReadPref) error
+}
+
+// watchMongo pings Mongo every interval and reports the blog service, and the
+// server as a whole, as NOT_SERVING while the ping fails, until stop is closed
+func watchMongo(client pinger, hs *health.Server, interval, timeout time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	current := healthpb.HealthCheckResponse_SERVING
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := client.Ping(ctx, nil)
+		cancel()
+
+		next := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			next = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if next == current {
+			continue
+		}
+
+		if err != nil {
+			slog.Warn("MongoDB ping failed", "status", next.String(), "error", err)
+		} else {
+			slog.Info("MongoDB reachable again", "status", next.String())
+		}
+		hs.SetServingStatus("", next)
+		hs.SetServingStatus(serviceName, next)
+		current = next
+	}
+}
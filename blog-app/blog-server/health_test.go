@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// fakePinger fails its pings while down is set
+type fakePinger struct {
+	down atomic.Bool
+}
+
+func (p *fakePinger) Ping(ctx context.Context, rp *readpref.ReadPref) error {
+	if p.down.Load() {
+		return errors.New("no reachable servers")
+	}
+	return nil
+}
+
+// waitForStatus polls the health server until service reports want
+func waitForStatus(t *testing.T, hs *health.Server, service string, want healthpb.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err == nil && resp.GetStatus() == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%q is %v, want %v", service, resp.GetStatus(), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWatchMongo(t *testing.T) {
+	hs := health.NewServer()
+	hs.SetServingStatus(serviceName, healthpb.HealthCheckResponse_SERVING)
+	client := &fakePinger{}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		watchMongo(client, hs, time.Millisecond, time.Second, stop)
+		close(done)
+	}()
+
+	client.down.Store(true)
+	waitForStatus(t, hs, "", healthpb.HealthCheckResponse_NOT_SERVING)
+	waitForStatus(t, hs, serviceName, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	client.down.Store(false)
+	waitForStatus(t, hs, "", healthpb.HealthCheckResponse_SERVING)
+	waitForStatus(t, hs, serviceName, healthpb.HealthCheckResponse_SERVING)
+
+	close(stop)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchMongo did not return after stop was closed")
+	}
+}
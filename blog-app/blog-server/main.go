@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
 	"os"
-	"os/signal"
+	"time"
 
 	"google.golang.org/grpc/codes"
 
@@ -16,43 +19,53 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 
 	pb "github.com/serhii12/grpc-go/blog-app/blogpb"
+	"github.com/serhii12/grpc-go/certs"
+	"github.com/serhii12/grpc-go/config"
+	"github.com/serhii12/grpc-go/deadline"
+	"github.com/serhii12/grpc-go/logging"
+	"github.com/serhii12/grpc-go/metrics"
+	"github.com/serhii12/grpc-go/ratelimit"
+	"github.com/serhii12/grpc-go/recovery"
+	"github.com/serhii12/grpc-go/requestid"
+	"github.com/serhii12/grpc-go/shutdown"
+	"github.com/serhii12/grpc-go/tracing"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
-var collection *mongo.Collection
-
-const (
-	port = ":50051"
-)
-
 // server is used to implement BlogServiceServer
 type server struct {
 	pb.UnimplementedBlogServiceServer
+	store *tenantStore
 }
 
 type blogItem struct {
 	ID       primitive.ObjectID `bson:"_id,omitempty"`
+	TenantID string             `bson:"tenant_id"`
 	AuthorID string             `bson:"author_id"`
 	Content  string             `bson:"content"`
 	Title    string             `bson:"title"`
 }
 
 func (s *server) CreateBlog(ctx context.Context, req *pb.CreateBlogRequest) (*pb.CreateBlogResponse, error) {
-	fmt.Println("Create blog request")
 	blog := req.GetBlog()
 
-	newBlog, err := collection.InsertOne(context.Background(), blogItem{
+	tenant, collection, err := s.store.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	newBlog, err := collection.InsertOne(ctx, blogItem{
+		TenantID: tenant,
 		AuthorID: blog.GetAuthorId(),
 		Content:  blog.GetContent(),
 		Title:    blog.GetTitle(),
 	})
 	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Failed to instert blog: %v", err),
-		)
+		return nil, storageError(ctx, err, codes.Internal, "Failed to instert blog")
 	}
 
 	bid, ok := newBlog.InsertedID.(primitive.ObjectID)
@@ -76,7 +89,10 @@ func (s *server) CreateBlog(ctx context.Context, req *pb.CreateBlogRequest) (*pb
 }
 
 func (s *server) ReadBlog(ctx context.Context, req *pb.ReadBlogRequest) (*pb.ReadBlogResponse, error) {
-	fmt.Println("Create blog request")
+	tenant, collection, err := s.store.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	bid, err := primitive.ObjectIDFromHex(req.GetBlogId())
 	if err != nil {
@@ -87,13 +103,10 @@ func (s *server) ReadBlog(ctx context.Context, req *pb.ReadBlogRequest) (*pb.Rea
 	}
 
 	blog := &blogItem{}
-	filter := bson.D{primitive.E{Key: "_id", Value: bid}}
-	result := collection.FindOne(context.Background(), filter)
+	filter := bson.D{primitive.E{Key: "_id", Value: bid}, primitive.E{Key: "tenant_id", Value: tenant}}
+	result := collection.FindOne(ctx, filter)
 	if err := result.Decode(blog); err != nil {
-		return nil, status.Errorf(
-			codes.NotFound,
-			fmt.Sprintf("Could not find a blog: %v", err),
-		)
+		return nil, storageError(ctx, err, codes.NotFound, "Could not find a blog")
 	}
 
 	resp := &pb.ReadBlogResponse{
@@ -109,7 +122,10 @@ func (s *server) ReadBlog(ctx context.Context, req *pb.ReadBlogRequest) (*pb.Rea
 }
 
 func (s *server) UpdateBlog(ctx context.Context, req *pb.UpdateBlogRequest) (*pb.UpdateBlogResponse, error) {
-	fmt.Println("Update blog request")
+	tenant, collection, err := s.store.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	bid, err := primitive.ObjectIDFromHex(req.GetBlog().GetId())
 	if err != nil {
@@ -119,7 +135,7 @@ func (s *server) UpdateBlog(ctx context.Context, req *pb.UpdateBlogRequest) (*pb
 		)
 	}
 
-	filter := bson.D{primitive.E{Key: "_id", Value: bid}}
+	filter := bson.D{primitive.E{Key: "_id", Value: bid}, primitive.E{Key: "tenant_id", Value: tenant}}
 	updateFields := bson.M{
 		"$set": bson.M{
 			"author_id": req.GetBlog().GetAuthorId(),
@@ -128,21 +144,14 @@ func (s *server) UpdateBlog(ctx context.Context, req *pb.UpdateBlogRequest) (*pb
 		},
 	}
 
-	if _, err := collection.UpdateOne(context.Background(), filter, updateFields); err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Failed to update a blog: %v", err),
-		)
+	if _, err := collection.UpdateOne(ctx, filter, updateFields); err != nil {
+		return nil, storageError(ctx, err, codes.Internal, "Failed to update a blog")
 	}
 
 	blog := &blogItem{}
-	findFilter := bson.D{primitive.E{Key: "_id", Value: bid}}
-	b := collection.FindOne(context.Background(), findFilter)
+	b := collection.FindOne(ctx, filter)
 	if err := b.Decode(blog); err != nil {
-		return nil, status.Errorf(
-			codes.NotFound,
-			fmt.Sprintf("Could not find a blog: %v", err),
-		)
+		return nil, storageError(ctx, err, codes.NotFound, "Could not find a blog")
 	}
 
 	resp := &pb.UpdateBlogResponse{
@@ -158,7 +167,10 @@ func (s *server) UpdateBlog(ctx context.Context, req *pb.UpdateBlogRequest) (*pb
 }
 
 func (s *server) DeleteBlog(ctx context.Context, req *pb.DeleteBlogRequest) (*pb.DeleteBlogResponse, error) {
-	fmt.Println("DeleteBlog blog request")
+	tenant, collection, err := s.store.scope(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	bid, err := primitive.ObjectIDFromHex(req.GetBlogId())
 	if err != nil {
@@ -168,12 +180,9 @@ func (s *server) DeleteBlog(ctx context.Context, req *pb.DeleteBlogRequest) (*pb
 		)
 	}
 
-	filter := bson.D{primitive.E{Key: "_id", Value: bid}}
-	if _, err := collection.DeleteOne(context.Background(), filter); err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Failed to delete a blog: %v", err),
-		)
+	filter := bson.D{primitive.E{Key: "_id", Value: bid}, primitive.E{Key: "tenant_id", Value: tenant}}
+	if _, err := collection.DeleteOne(ctx, filter); err != nil {
+		return nil, storageError(ctx, err, codes.Internal, "Failed to delete a blog")
 	}
 
 	resp := &pb.DeleteBlogResponse{
@@ -184,19 +193,25 @@ func (s *server) DeleteBlog(ctx context.Context, req *pb.DeleteBlogRequest) (*pb
 }
 
 func (s *server) ListBlog(req *pb.ListBlogRequest, stream pb.BlogService_ListBlogServer) error {
-	fmt.Println("List blog request")
+	ctx := stream.Context()
+	tenant, collection, err := s.store.scope(ctx)
+	if err != nil {
+		return err
+	}
 
-	cur, err := collection.Find(context.Background(), primitive.D{{}})
+	cur, err := collection.Find(ctx, bson.D{primitive.E{Key: "tenant_id", Value: tenant}})
 	if err != nil {
-		return status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Could not find blogs: %v", err),
-		)
+		return storageError(ctx, err, codes.Internal, "Could not find blogs")
 	}
 
-	defer cur.Close(context.Background())
+	defer cur.Close(ctx)
 
-	for cur.Next(context.Background()) {
+	for cur.Next(ctx) {
+		// documents already fetched in a batch are returned without
+		// checking ctx, so stop here once the client is gone
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
 
 		data := &blogItem{}
 		if err := cur.Decode(data); err != nil {
@@ -212,6 +227,11 @@ func (s *server) ListBlog(req *pb.ListBlogRequest, stream pb.BlogService_ListBlo
 			Content:  data.Content,
 			Title:    data.Title,
 		}}); err != nil {
+			// a client that cancelled or ran out of time ended the stream
+			// itself, which is not a server error
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return status.FromContextError(ctxErr).Err()
+			}
 			return status.Errorf(
 				codes.Internal,
 				fmt.Sprintf("Failed to send data: %v", err),
@@ -220,10 +240,7 @@ func (s *server) ListBlog(req *pb.ListBlogRequest, stream pb.BlogService_ListBlo
 	}
 
 	if err := cur.Err(); err != nil {
-		return status.Errorf(
-			codes.Internal,
-			fmt.Sprintf("Unknown internal error: %v", err),
-		)
+		return storageError(ctx, err, codes.Internal, "Unknown internal error")
 	}
 
 	return nil
@@ -233,8 +250,39 @@ func main() {
 	// if we crash the go code, we get the file name and line number
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
-	fmt.Println("Connecting to MongoDB")
-	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
+	cfg := defaultConfig()
+	if err := config.Load("blog", cfg, os.Args[1:]); err != nil {
+		// -h already printed the usage
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		log.Fatal(err)
+	}
+	fmt.Printf("Effective config:\n%s", config.Format(cfg))
+
+	logger, err := logging.New(cfg.Log)
+	if err != nil {
+		log.Fatal(err)
+	}
+	slog.SetDefault(logger)
+
+	serverMetrics := metrics.New()
+	metricsServer, err := serverMetrics.Serve(cfg.Metrics)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	stopTracing, err := tracing.Setup(context.Background(), "blog-server", cfg.Tracing)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	slog.Info("Connecting to MongoDB")
+	monitor := serverMetrics.MongoMonitor()
+	if cfg.Tracing.Enabled {
+		monitor = combineMonitors(monitor, tracing.MongoMonitor())
+	}
+	clientOptions := options.Client().ApplyURI(cfg.Mongo.URI).SetMonitor(monitor)
 
 	// Connect to MongoDB
 	client, err := mongo.Connect(context.TODO(), clientOptions)
@@ -247,44 +295,92 @@ func main() {
 		log.Fatal(err)
 	}
 
-	fmt.Println("Connected to MongoDB!")
+	slog.Info("Connected to MongoDB")
 
-	fmt.Println("Blog Service Started")
-	collection = client.Database("mydb").Collection("blog")
+	store := newTenantStore(client, cfg)
+	if cfg.Tenancy.LegacyTenant != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		migrated, err := store.migrateLegacyBlogs(ctx, cfg.Tenancy.LegacyTenant)
+		cancel()
+		if err != nil {
+			log.Fatalf("Failed to migrate blogs without a tenant: %v", err)
+		}
+		if migrated > 0 {
+			slog.Info("Migrated blogs without a tenant", "tenant", cfg.Tenancy.LegacyTenant, "count", migrated)
+		}
+	}
 
-	lis, err := net.Listen("tcp", port)
+	lis, err := net.Listen("tcp", cfg.Server.Address)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	opts := []grpc.ServerOption{}
+	opts, err := certs.ServerOptions(cfg.Server.TLS)
+	if err != nil {
+		log.Fatalf("failed to load TLS credentials: %v", err)
+	}
+	limiter := ratelimit.New(cfg.RateLimit)
+	deadlines := deadline.New(cfg.Deadlines)
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(
+			requestid.UnaryServerInterceptor(),
+			logging.UnaryServerInterceptor(logger, cfg.Log),
+			serverMetrics.UnaryServerInterceptor(),
+			limiter.UnaryServerInterceptor(),
+			deadlines.UnaryServerInterceptor(),
+			recovery.UnaryServerInterceptor(serverMetrics),
+		),
+		grpc.ChainStreamInterceptor(
+			requestid.StreamServerInterceptor(),
+			logging.StreamServerInterceptor(logger, cfg.Log),
+			serverMetrics.StreamServerInterceptor(),
+			limiter.StreamServerInterceptor(),
+			deadlines.StreamServerInterceptor(),
+			recovery.StreamServerInterceptor(serverMetrics),
+		),
+	)
+
+	if cfg.Tracing.Enabled {
+		opts = append(opts, tracing.ServerOption())
+	}
+
 	s := grpc.NewServer(opts...)
-	pb.RegisterBlogServiceServer(s, &server{})
+	pb.RegisterBlogServiceServer(s, &server{store: store})
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(serviceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, healthServer)
+
+	stopHealth := make(chan struct{})
+	go watchMongo(client, healthServer, cfg.Mongo.PingInterval, cfg.Mongo.PingTimeout, stopHealth)
 	// Register reflection service on gRPC server.
 	reflection.Register(s)
 
 	go func() {
-		fmt.Println("Starting Server")
+		slog.Info("Blog Service started", "address", lis.Addr().String())
 
 		if err := s.Serve(lis); err != nil {
 			log.Fatalf("Failed to serve: %v", err)
 		}
 	}()
 
-	// Wait for Control C to exit
-	ch := make(chan os.Signal, 1)
-	signal.Notify(ch, os.Interrupt)
-
-	// Block until a signal is received
-	<-ch
-	fmt.Println("Stopping the server")
-	s.Stop()
-	fmt.Println("Closing the listener")
-	lis.Close()
-	fmt.Println("Closing MongoDB Connection")
+	// Block until Control C or SIGTERM is received
+	sig := shutdown.Wait()
+	slog.Info("Stopping the server", "signal", sig.String())
+	close(stopHealth)
+	shutdown.Graceful(s, healthServer, cfg.Server.ShutdownTimeout)
+	if metricsServer != nil {
+		metricsServer.Close()
+	}
+
+	// MongoDB is closed last, once no RPC can still be using it
+	slog.Info("Closing MongoDB connection")
 	if err := client.Disconnect(context.TODO()); err != nil {
 		log.Fatal(err)
 	}
-	fmt.Println("Connection to MongoDB closed.")
-	fmt.Println("End of Program")
+	slog.Info("Connection to MongoDB closed")
+
+	if err := stopTracing(context.Background()); err != nil {
+		slog.Error("Failed to flush spans", "error", err)
+	}
 }
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	pb "github.com/serhii12/grpc-go/blog-app/blogpb"
+	"github.com/serhii12/grpc-go/internal/grpctest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// startServer serves the blog service over an in-memory connection, with
+// its blogs in an in-memory store
+func startServer(t *testing.T) (pb.BlogServiceClient, *tenantStore, *grpctest.Server) {
+	t.Helper()
+	cfg := defaultConfig()
+	cfg.Tenancy.AllowMetadata = true
+	store, _ := newTestStore(cfg)
+
+	s := grpctest.NewServer(t, func(s *grpc.Server) {
+		pb.RegisterBlogServiceServer(s, &server{store: store})
+	})
+	return pb.NewBlogServiceClient(s.Conn), store, s
+}
+
+func TestListBlogSurvivesClientDisconnect(t *testing.T) {
+	c, store, s := startServer(t)
+	const tenant = "team-a"
+	md := metadata.Pairs(tenantMetadataKey, tenant)
+
+	// far more than fits in the flow control window, so the handler is
+	// still sending when the client goes away
+	for i := 0; i < 2000; i++ {
+		blog := blogItem{TenantID: tenant, AuthorID: "ann", Title: fmt.Sprint("Blog ", i), Content: strings.Repeat("x", 1024)}
+		if _, err := store.collection(tenant).InsertOne(context.Background(), blog); err != nil {
+			t.Fatalf("InsertOne: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(context.Background(), md))
+	stream, err := c.ListBlog(ctx, &pb.ListBlogRequest{})
+	if err != nil {
+		t.Fatalf("ListBlog: %v", err)
+	}
+	first, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	cancel()
+
+	if h := s.Wait(t, "/blog.BlogService/ListBlog"); h.Code != codes.Canceled {
+		t.Errorf("handler returned %v, want Canceled", h.Code)
+	}
+
+	ctx = metadata.NewOutgoingContext(context.Background(), md)
+	resp, err := c.ReadBlog(ctx, &pb.ReadBlogRequest{BlogId: first.GetBlog().GetId()})
+	if err != nil {
+		t.Fatalf("ReadBlog after the disconnect: %v", err)
+	}
+	if resp.GetBlog().GetTitle() != first.GetBlog().GetTitle() {
+		t.Errorf("ReadBlog = %q, want %q", resp.GetBlog().GetTitle(), first.GetBlog().GetTitle())
+	}
+}
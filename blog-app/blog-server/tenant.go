@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/serhii12/grpc-go/certs"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tenantMetadataKey is the request metadata key carrying the tenant ID
+const tenantMetadataKey = "x-tenant-id"
+
+// tenantConfig selects where a tenant's blogs are stored.
+// Empty fields fall back to the shared mongo.database and mongo.collection.
+type tenantConfig struct {
+	Database   string `yaml:"database"`
+	Collection string `yaml:"collection"`
+}
+
+// tenancyConfig decides how requests name their tenant
+type tenancyConfig struct {
+	AllowMetadata bool   `yaml:"allow_metadata" usage:"let clients without a certificate name their tenant in x-tenant-id metadata, which lets any client act as any tenant"`
+	DefaultTenant string `yaml:"default_tenant" usage:"tenant of clients that neither present a certificate nor name a tenant in metadata, empty to reject them"`
+	LegacyTenant  string `yaml:"legacy_tenant" usage:"tenant that blogs stored without a tenant are assigned to at startup, empty to leave them invisible"`
+}
+
+// collection is the part of *mongo.Collection the blog service uses, so
+// tests can store blogs without a MongoDB
+type collection interface {
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error)
+	UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	UpdateMany(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+}
+
+// tenantStore resolves the Mongo collection for a tenant
+type tenantStore struct {
+	// open returns a collection of a database
+	open              func(database, collection string) collection
+	defaultDatabase   string
+	defaultCollection string
+	tenants           map[string]tenantConfig
+	allowMetadata     bool
+	defaultTenant     string
+}
+
+func newTenantStore(client *mongo.Client, cfg *serverConfig) *tenantStore {
+	return &tenantStore{
+		open: func(database, coll string) collection {
+			return client.Database(database).Collection(coll)
+		},
+		defaultDatabase:   cfg.Mongo.Database,
+		defaultCollection: cfg.Mongo.Collection,
+		tenants:           cfg.Tenants,
+		allowMetadata:     cfg.Tenancy.AllowMetadata,
+		defaultTenant:     cfg.Tenancy.DefaultTenant,
+	}
+}
+
+// collection returns the collection holding the given tenant's blogs
+func (ts *tenantStore) collection(tenant string) collection {
+	db, coll := ts.defaultDatabase, ts.defaultCollection
+	if cfg, ok := ts.tenants[tenant]; ok {
+		if cfg.Database != "" {
+			db = cfg.Database
+		}
+		if cfg.Collection != "" {
+			coll = cfg.Collection
+		}
+	}
+
+	return ts.open(db, coll)
+}
+
+// tenant returns the tenant of the request. A client authenticated with a
+// certificate is the tenant named by its principal and cannot claim another
+// one through metadata; other clients may only name their tenant in
+// metadata when tenancy.allow_metadata trusts them to, and are the default
+// tenant when they name none.
+func (ts *tenantStore) tenant(ctx context.Context) (string, error) {
+	var requested string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(tenantMetadataKey); len(values) > 0 {
+			requested = strings.TrimSpace(values[0])
+		}
+	}
+
+	if principal, ok := certs.PrincipalFromContext(ctx); ok {
+		if requested != "" && requested != principal {
+			return "", status.Errorf(codes.PermissionDenied, "%s may not act as tenant %q", principal, requested)
+		}
+		return principal, nil
+	}
+
+	if requested == "" && ts.defaultTenant != "" {
+		return ts.defaultTenant, nil
+	}
+	if !ts.allowMetadata {
+		return "", status.Error(codes.Unauthenticated, "A client certificate is required to name a tenant")
+	}
+	if requested == "" {
+		return "", status.Errorf(codes.InvalidArgument, "missing %s metadata", tenantMetadataKey)
+	}
+
+	return requested, nil
+}
+
+// scope returns the tenant of the request together with its collection.
+// Every storage operation must go through it and filter on the tenant ID,
+// so tenants sharing a collection never see each other's blogs.
+func (ts *tenantStore) scope(ctx context.Context) (string, collection, error) {
+	tenant, err := ts.tenant(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return tenant, ts.collection(tenant), nil
+}
+
+// migrateLegacyBlogs assigns blogs stored before tenants existed, which have
+// no tenant_id and can only be in the shared collection, to the given tenant.
+// Once they are migrated it changes nothing, so it runs on every start.
+func (ts *tenantStore) migrateLegacyBlogs(ctx context.Context, tenant string) (int64, error) {
+	result, err := ts.open(ts.defaultDatabase, ts.defaultCollection).UpdateMany(ctx,
+		bson.M{"tenant_id": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"tenant_id": tenant}},
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
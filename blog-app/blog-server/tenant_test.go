@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	pb "github.com/serhii12/grpc-go/blog-app/blogpb"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// tenantContext returns the context of a request from a client with the
+// given certificate principal and x-tenant-id metadata, each empty for none
+func tenantContext(principal, tenant string) context.Context {
+	ctx := context.Background()
+	if principal != "" {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: principal}}
+		ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+		}})
+	}
+	if tenant != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(tenantMetadataKey, tenant))
+	}
+
+	return ctx
+}
+
+func TestTenant(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowMetadata bool
+		defaultTenant string
+		principal     string
+		requested     string
+		want          string
+		code          codes.Code
+	}{
+		{name: "certificate", principal: "team-a", want: "team-a"},
+		{name: "certificate naming its own tenant", principal: "team-a", requested: "team-a", want: "team-a"},
+		{name: "certificate naming another tenant", principal: "team-a", requested: "team-b", code: codes.PermissionDenied},
+		{name: "certificate with metadata allowed", allowMetadata: true, principal: "team-a", requested: "team-b", code: codes.PermissionDenied},
+		{name: "default tenant", defaultTenant: "default", want: "default"},
+		{name: "metadata not allowed", defaultTenant: "default", requested: "team-b", code: codes.Unauthenticated},
+		{name: "metadata", allowMetadata: true, defaultTenant: "default", requested: "team-b", want: "team-b"},
+		{name: "metadata missing", allowMetadata: true, code: codes.InvalidArgument},
+		{name: "no tenant at all", code: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultConfig()
+			cfg.Tenancy.AllowMetadata = tt.allowMetadata
+			cfg.Tenancy.DefaultTenant = tt.defaultTenant
+			store, _ := newTestStore(cfg)
+
+			got, err := store.tenant(tenantContext(tt.principal, tt.requested))
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got %v, want %v", err, tt.code)
+			}
+			if got != tt.want {
+				t.Errorf("tenant = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// listStream collects what ListBlog sends
+type listStream struct {
+	grpc.ServerStream
+	ctx   context.Context
+	blogs []*pb.Blog
+}
+
+func (ls *listStream) Context() context.Context { return ls.ctx }
+
+func (ls *listStream) Send(resp *pb.ListBlogResponse) error {
+	ls.blogs = append(ls.blogs, resp.GetBlog())
+	return nil
+}
+
+func TestTenantsDoNotSeeEachOthersBlogs(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Tenancy.AllowMetadata = true
+	cfg.Tenants = map[string]tenantConfig{"team-c": {Database: "team_c"}}
+	store, fm := newTestStore(cfg)
+	s := &server{store: store}
+
+	teamA, teamB := tenantContext("", "team-a"), tenantContext("", "team-b")
+	created, err := s.CreateBlog(teamA, &pb.CreateBlogRequest{Blog: &pb.Blog{AuthorId: "ann", Title: "A's blog"}})
+	if err != nil {
+		t.Fatalf("CreateBlog: %v", err)
+	}
+	id := created.GetBlog().GetId()
+
+	if _, err := s.ReadBlog(teamA, &pb.ReadBlogRequest{BlogId: id}); err != nil {
+		t.Errorf("ReadBlog by its tenant: %v", err)
+	}
+	if _, err := s.ReadBlog(teamB, &pb.ReadBlogRequest{BlogId: id}); status.Code(err) != codes.NotFound {
+		t.Errorf("ReadBlog by another tenant: got %v, want NotFound", err)
+	}
+	if _, err := s.UpdateBlog(teamB, &pb.UpdateBlogRequest{Blog: &pb.Blog{Id: id, Title: "taken"}}); status.Code(err) != codes.NotFound {
+		t.Errorf("UpdateBlog by another tenant: got %v, want NotFound", err)
+	}
+	if _, err := s.DeleteBlog(teamB, &pb.DeleteBlogRequest{BlogId: id}); err != nil {
+		t.Errorf("DeleteBlog by another tenant: %v", err)
+	}
+
+	stream := &listStream{ctx: teamB}
+	if err := s.ListBlog(&pb.ListBlogRequest{}, stream); err != nil {
+		t.Fatalf("ListBlog: %v", err)
+	}
+	if len(stream.blogs) != 0 {
+		t.Errorf("another tenant lists %v", stream.blogs)
+	}
+
+	resp, err := s.ReadBlog(teamA, &pb.ReadBlogRequest{BlogId: id})
+	if err != nil {
+		t.Fatalf("ReadBlog after the other tenant's changes: %v", err)
+	}
+	if resp.GetBlog().GetTitle() != "A's blog" {
+		t.Errorf("title = %q, want the original", resp.GetBlog().GetTitle())
+	}
+
+	// a tenant with a database of its own does not touch the shared one
+	if _, err := s.CreateBlog(tenantContext("", "team-c"), &pb.CreateBlogRequest{Blog: &pb.Blog{Title: "C's blog"}}); err != nil {
+		t.Fatalf("CreateBlog: %v", err)
+	}
+	if n := len(fm.collection("team_c", cfg.Mongo.Collection).find(bson.M{})); n != 1 {
+		t.Errorf("team-c's database holds %d blogs, want 1", n)
+	}
+	if n := len(fm.collection(cfg.Mongo.Database, cfg.Mongo.Collection).find(bson.M{})); n != 1 {
+		t.Errorf("the shared collection holds %d blogs, want 1", n)
+	}
+}
+
+func TestMigrateLegacyBlogs(t *testing.T) {
+	cfg := defaultConfig()
+	store, fm := newTestStore(cfg)
+	shared := fm.collection(cfg.Mongo.Database, cfg.Mongo.Collection)
+	ctx := context.Background()
+
+	legacy, err := shared.InsertOne(ctx, bson.M{"title": "from before tenants"})
+	if err != nil {
+		t.Fatalf("InsertOne: %v", err)
+	}
+	if _, err := shared.InsertOne(ctx, blogItem{TenantID: "team-a", Title: "A's blog"}); err != nil {
+		t.Fatalf("InsertOne: %v", err)
+	}
+
+	for _, want := range []int64{1, 0} {
+		migrated, err := store.migrateLegacyBlogs(ctx, "default")
+		if err != nil {
+			t.Fatalf("migrateLegacyBlogs: %v", err)
+		}
+		if migrated != want {
+			t.Errorf("migrated %d blogs, want %d", migrated, want)
+		}
+	}
+
+	s := &server{store: store}
+	id := legacy.InsertedID.(primitive.ObjectID).Hex()
+	resp, err := s.ReadBlog(tenantContext("", ""), &pb.ReadBlogRequest{BlogId: id})
+	if err != nil {
+		t.Fatalf("ReadBlog as the default tenant: %v", err)
+	}
+	if resp.GetBlog().GetTitle() != "from before tenants" {
+		t.Errorf("title = %q", resp.GetBlog().GetTitle())
+	}
+}
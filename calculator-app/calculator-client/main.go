@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -9,13 +10,22 @@ import (
 
 	"github.com/serhii12/grpc-go/calculator-app/calculatorpb"
 	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
-	"google.golang.org/grpc"
+	"github.com/serhii12/grpc-go/client"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	address = "localhost:50051"
 )
 
+var (
+	mode = flag.String("mode", "bidi", "call to make: unary, server-stream, client-stream, bidi or sqrt")
+
+	clientConfig client.Config
+)
+
 func doUnary(ctx context.Context, c calculatorpb.CalculatorServiceClient) {
 	fmt.Println("Starting to do a Sum Unary RPC...")
 
@@ -129,18 +139,68 @@ func doBIStreaming(ctx context.Context, c calculatorpb.CalculatorServiceClient)
 	<-waitc
 }
 
+func doErrorUnary(ctx context.Context, c calculatorpb.CalculatorServiceClient) {
+	fmt.Println("Starting to do a SquareRoot Unary RPC...")
+
+	requests := []*pb.SquareRootRequest{
+		{Input: &pb.SquareRootRequest_Number{Number: 10}},
+		{Input: &pb.SquareRootRequest_DoubleNumber{DoubleNumber: 2.25}},
+		{Input: &pb.SquareRootRequest_Number{Number: -2}},
+	}
+
+	for _, req := range requests {
+		res, err := c.SquareRoot(ctx, req)
+		if err == nil {
+			fmt.Printf("Square root of %v: %v\n", req, res.GetNumberRoot())
+			continue
+		}
+
+		st := status.Convert(err)
+		if st.Code() != codes.InvalidArgument {
+			log.Fatalf("Big error calling SquareRoot: %v", err)
+		}
+
+		violations := 0
+		for _, d := range st.Details() {
+			if br, ok := d.(*errdetails.BadRequest); ok {
+				for _, v := range br.GetFieldViolations() {
+					fmt.Printf("Invalid %s: %s\n", v.GetField(), v.GetDescription())
+					violations++
+				}
+			}
+		}
+		if violations == 0 {
+			fmt.Printf("Invalid argument: %v\n", st.Message())
+		}
+	}
+}
+
 func main() {
+	clientConfig.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
 	// Set up a connection to the server.
-	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock())
+	conn, err := client.Dial("calculator-client", address, clientConfig)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
 	defer conn.Close()
 
-	c := pb.NewCalculatorServiceClient(conn)
-
-	// doUnary(context.Background(), c)
-	// doServerStreaming(context.Background(), c)
-	// doClientStreaming(context.Background(), c)
-	doBIStreaming(context.Background(), c)
+	c := pb.NewCalculatorServiceClient(conn.ClientConn)
+
+	ctx := context.Background()
+	switch *mode {
+	case "unary":
+		doUnary(ctx, c)
+	case "server-stream":
+		doServerStreaming(ctx, c)
+	case "client-stream":
+		doClientStreaming(ctx, c)
+	case "bidi":
+		doBIStreaming(ctx, c)
+	case "sqrt":
+		doErrorUnary(ctx, c)
+	default:
+		log.Fatalf("unknown mode %q", *mode)
+	}
 }
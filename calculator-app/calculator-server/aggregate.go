@@ -0,0 +1,304 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"github.com/serhii12/grpc-go/streamerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// entry is a number in a window, seq tells equal numbers apart
+type entry struct {
+	value float64
+	at    time.Time
+	seq   int64
+}
+
+// windowState holds the numbers of one window in arrival order. The
+// monotonic queues keep max and min at their front, so evicting the
+// oldest number never needs a scan of the window.
+type windowState struct {
+	entries    []entry
+	maxQ, minQ []entry
+	count      int64
+
+	// Neumaier's compensated sum, so a large number that left the
+	// window does not take the precision of the rest with it
+	sum          float64
+	compensation float64
+}
+
+func (w *windowState) addToSum(x float64) {
+	t := w.sum + x
+	if math.Abs(w.sum) >= math.Abs(x) {
+		w.compensation += (w.sum - t) + x
+	} else {
+		w.compensation += (x - t) + w.sum
+	}
+	w.sum = t
+}
+
+func (w *windowState) push(e entry) {
+	w.entries = append(w.entries, e)
+	w.count++
+	w.addToSum(e.value)
+
+	for len(w.maxQ) > 0 && w.maxQ[len(w.maxQ)-1].value <= e.value {
+		w.maxQ = w.maxQ[:len(w.maxQ)-1]
+	}
+	w.maxQ = append(w.maxQ, e)
+
+	for len(w.minQ) > 0 && w.minQ[len(w.minQ)-1].value >= e.value {
+		w.minQ = w.minQ[:len(w.minQ)-1]
+	}
+	w.minQ = append(w.minQ, e)
+}
+
+// pop evicts the oldest number
+func (w *windowState) pop() {
+	e := w.entries[0]
+	w.entries = w.entries[1:]
+	w.count--
+	w.addToSum(-e.value)
+	if w.count == 0 {
+		// drop the rounding error a sliding sum picks up
+		w.sum, w.compensation = 0, 0
+	}
+
+	if w.maxQ[0].seq == e.seq {
+		w.maxQ = w.maxQ[1:]
+	}
+	if w.minQ[0].seq == e.seq {
+		w.minQ = w.minQ[1:]
+	}
+}
+
+// forget drops what only eviction needs, for windows that never evict
+func (w *windowState) forget() {
+	w.entries = w.entries[:0]
+	w.maxQ = w.maxQ[:1]
+	w.minQ = w.minQ[:1]
+}
+
+func (w *windowState) value(kind pb.Aggregate) float64 {
+	switch kind {
+	case pb.Aggregate_AGGREGATE_MAX:
+		return w.maxQ[0].value
+	case pb.Aggregate_AGGREGATE_MIN:
+		return w.minQ[0].value
+	case pb.Aggregate_AGGREGATE_MEAN:
+		return (w.sum + w.compensation) / float64(w.count)
+	default:
+		return w.sum + w.compensation
+	}
+}
+
+// aggregator applies the window of one RunningAggregate call
+type aggregator struct {
+	kind     pb.Aggregate
+	count    int64
+	duration time.Duration
+	tumbling bool
+	limit    int
+
+	window windowState
+	seq    int64
+}
+
+func (s *server) newAggregator(req *pb.RunningAggregateRequest) (*aggregator, error) {
+	a := &aggregator{
+		kind:     req.GetAggregate(),
+		count:    int64(req.GetWindow().GetCount()),
+		tumbling: req.GetWindow().GetTumbling(),
+		limit:    int(s.aggregate.MaxWindowCount),
+	}
+
+	switch a.kind {
+	case pb.Aggregate_AGGREGATE_MAX, pb.Aggregate_AGGREGATE_MIN, pb.Aggregate_AGGREGATE_MEAN, pb.Aggregate_AGGREGATE_SUM:
+	case pb.Aggregate_AGGREGATE_UNSPECIFIED:
+		return nil, invalidArgument("aggregate", "is required")
+	default:
+		return nil, invalidArgument("aggregate", fmt.Sprintf("unknown aggregate %v", a.kind))
+	}
+
+	switch size := req.GetWindow().GetSize().(type) {
+	case *pb.Window_Count:
+		if size.Count < 1 || size.Count > s.aggregate.MaxWindowCount {
+			return nil, invalidArgument("window.count", fmt.Sprintf("must be between 1 and %d", s.aggregate.MaxWindowCount))
+		}
+	case *pb.Window_DurationMs:
+		// checked in milliseconds, a large duration_ms overflows a Duration
+		if maxMs := s.aggregate.MaxWindowDuration.Milliseconds(); size.DurationMs < 1 || size.DurationMs > maxMs {
+			return nil, invalidArgument("window.duration_ms", fmt.Sprintf("must be between 1 and %d", maxMs))
+		}
+		a.duration = time.Duration(size.DurationMs) * time.Millisecond
+	default:
+		if a.tumbling {
+			return nil, invalidArgument("window.tumbling", "needs a count or a duration")
+		}
+	}
+
+	return a, nil
+}
+
+// add takes the next number and returns the update it causes, if any
+func (a *aggregator) add(x float64, now time.Time) (*pb.RunningAggregateResponse, error) {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return nil, invalidArgument("number", fmt.Sprintf("must be a finite number, got %v", x))
+	}
+
+	a.seq++
+	a.window.push(entry{value: x, at: now, seq: a.seq})
+
+	switch {
+	case a.count == 0 && a.duration == 0:
+		a.window.forget()
+	case a.tumbling && a.count > 0:
+		if a.window.count < a.count {
+			return nil, nil
+		}
+		return a.close()
+	case a.count > 0:
+		for a.window.count > a.count {
+			a.window.pop()
+		}
+	default:
+		if !a.tumbling {
+			for a.window.entries[0].at.Before(now.Add(-a.duration)) {
+				a.window.pop()
+			}
+		}
+		if len(a.window.entries) > a.limit {
+			return nil, status.Errorf(
+				codes.ResourceExhausted,
+				fmt.Sprintf("A window may hold at most %d numbers", a.limit),
+			)
+		}
+		if a.tumbling {
+			return nil, nil
+		}
+	}
+
+	return a.result()
+}
+
+// close reports a tumbling window and starts the next one empty
+func (a *aggregator) close() (*pb.RunningAggregateResponse, error) {
+	if a.window.count == 0 {
+		return nil, nil
+	}
+
+	resp, err := a.result()
+	a.window = windowState{}
+
+	return resp, err
+}
+
+func (a *aggregator) result() (*pb.RunningAggregateResponse, error) {
+	v := a.window.value(a.kind)
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil, status.Error(codes.OutOfRange, "The aggregate of these numbers does not fit in a double")
+	}
+
+	return &pb.RunningAggregateResponse{Value: v, Count: a.window.count}, nil
+}
+
+// RunningAggregate implements calculator.CalculatorServiceServer
+func (s *server) RunningAggregate(stream pb.CalculatorService_RunningAggregateServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return streamerr.Status(err, "read the client stream")
+	}
+
+	agg, err := s.newAggregator(first)
+	if err != nil {
+		return err
+	}
+
+	// mu guards agg and stream.Send, which the window timer uses too
+	var mu sync.Mutex
+	send := func(resp *pb.RunningAggregateResponse, err error) error {
+		if err != nil || resp == nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return streamerr.Status(err, "send data to the client")
+		}
+		return nil
+	}
+
+	if err := send(agg.add(first.GetNumber(), time.Now())); err != nil {
+		return err
+	}
+
+	// tumbling time windows close on a timer, also while no numbers
+	// arrive, so a goroutine closes them while the handler reads the
+	// stream. It is stopped before the handler returns, since the stream
+	// must not be used after that.
+	timerErr := make(chan error, 1)
+	if agg.tumbling && agg.duration > 0 {
+		stop, stopped := make(chan struct{}), make(chan struct{})
+		defer func() {
+			close(stop)
+			<-stopped
+		}()
+
+		go func() {
+			defer close(stopped)
+			ticker := time.NewTicker(agg.duration)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					mu.Lock()
+					err := send(agg.close())
+					mu.Unlock()
+					if err != nil {
+						timerErr <- err
+						return
+					}
+				case <-stop:
+					return
+				}
+			}
+		}()
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			// the client is done, report the last partial window
+			if !agg.tumbling {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			return send(agg.close())
+		}
+		if err != nil {
+			return streamerr.Status(err, "read the client stream")
+		}
+
+		select {
+		case err := <-timerErr:
+			return err
+		default:
+		}
+
+		mu.Lock()
+		err = send(agg.add(req.GetNumber(), time.Now()))
+		mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+}
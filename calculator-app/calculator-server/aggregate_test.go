@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSlidingSumAfterLargeNumber(t *testing.T) {
+	s := newServer(defaultConfig())
+	agg, err := s.newAggregator(&pb.RunningAggregateRequest{
+		Aggregate: pb.Aggregate_AGGREGATE_MEAN,
+		Window:    &pb.Window{Size: &pb.Window_Count{Count: 2}},
+	})
+	if err != nil {
+		t.Fatalf("newAggregator: %v", err)
+	}
+
+	// 1e17 swallows the first 1 when added and must give it back when evicted
+	var resp *pb.RunningAggregateResponse
+	for _, x := range []float64{1e17, 1, 1} {
+		if resp, err = agg.add(x, time.Now()); err != nil {
+			t.Fatalf("add(%v): %v", x, err)
+		}
+	}
+	if resp.GetValue() != 1 || resp.GetCount() != 2 {
+		t.Errorf("mean = %v of %d numbers, want 1 of 2", resp.GetValue(), resp.GetCount())
+	}
+}
+
+func TestNewAggregatorDuration(t *testing.T) {
+	s := newServer(defaultConfig())
+	tests := []struct {
+		name       string
+		durationMs int64
+		wantErr    bool
+	}{
+		{name: "shortest", durationMs: 1},
+		{name: "longest", durationMs: time.Hour.Milliseconds()},
+		{name: "zero", durationMs: 0, wantErr: true},
+		{name: "negative", durationMs: -1, wantErr: true},
+		{name: "too long", durationMs: time.Hour.Milliseconds() + 1, wantErr: true},
+		// in nanoseconds this wraps around to just over a second
+		{name: "overflows a Duration", durationMs: 18446744074710, wantErr: true},
+		{name: "largest", durationMs: math.MaxInt64, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.newAggregator(&pb.RunningAggregateRequest{
+				Aggregate: pb.Aggregate_AGGREGATE_SUM,
+				Window:    &pb.Window{Size: &pb.Window_DurationMs{DurationMs: tt.durationMs}},
+			})
+			if tt.wantErr && status.Code(err) != codes.InvalidArgument {
+				t.Errorf("got %v, want InvalidArgument", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("newAggregator: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunningAggregateTumblingTime(t *testing.T) {
+	c, s := startServer(t)
+
+	stream, err := c.RunningAggregate(context.Background())
+	if err != nil {
+		t.Fatalf("RunningAggregate: %v", err)
+	}
+	window := &pb.Window{Size: &pb.Window_DurationMs{DurationMs: 50}, Tumbling: true}
+	if err := stream.Send(&pb.RunningAggregateRequest{Aggregate: pb.Aggregate_AGGREGATE_SUM, Window: window, Number: 1}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if err := stream.Send(&pb.RunningAggregateRequest{Number: 2}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	// the timer closes windows while the client sends nothing, the two
+	// numbers usually share one
+	var sum float64
+	for count := int64(0); count < 2; {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		sum += resp.GetValue()
+		count += resp.GetCount()
+	}
+	if sum != 3 {
+		t.Errorf("windows add up to %v, want 3", sum)
+	}
+
+	// the partial window is reported when the client is done
+	if err := stream.Send(&pb.RunningAggregateRequest{Number: 4}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend: %v", err)
+	}
+	var last *pb.RunningAggregateResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+		last = resp
+	}
+	if last.GetValue() != 4 || last.GetCount() != 1 {
+		t.Errorf("last window = %v of %d numbers, want 4 of 1", last.GetValue(), last.GetCount())
+	}
+
+	if h := s.Wait(t, "/calculator.CalculatorService/RunningAggregate"); h.Code != codes.OK {
+		t.Errorf("handler returned %v, want OK", h.Code)
+	}
+}
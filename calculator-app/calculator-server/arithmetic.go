@@ -0,0 +1,482 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxFactorial bounds Factorial's input, 10000! already has 35660 digits
+const maxFactorial = 10000
+
+// maxPowerBits bounds the size of decimal powers to about that of the
+// largest factorial
+const maxPowerBits = 1 << 17
+
+// operand is a request Number, held as an int64, a float64 or,
+// for decimals, a big.Rat
+type operand struct {
+	isInt bool
+	i     int64
+	f     float64
+	d     *big.Rat
+}
+
+func (o operand) float() float64 {
+	switch {
+	case o.isInt:
+		return float64(o.i)
+	case o.d != nil:
+		f, _ := o.d.Float64()
+		return f
+	}
+
+	return o.f
+}
+
+func (o operand) isZero() bool {
+	if o.d != nil {
+		return o.d.Sign() == 0
+	}
+
+	return o.float() == 0
+}
+
+// rat returns a copy of the operand as a big.Rat, taking reals at their
+// shortest decimal form so that 0.1 stays 0.1
+func (o operand) rat() *big.Rat {
+	switch {
+	case o.isInt:
+		return new(big.Rat).SetInt64(o.i)
+	case o.d != nil:
+		return new(big.Rat).Set(o.d)
+	}
+
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(o.f, 'g', -1, 64))
+	return r
+}
+
+// decimals reports whether the operation is on decimals,
+// which makes it exact and its result a decimal
+func decimals(a, b operand) bool {
+	return a.d != nil || b.d != nil
+}
+
+func readOperand(field string, n *pb.Number) (operand, error) {
+	switch v := n.GetValue().(type) {
+	case *pb.Number_Integer:
+		return operand{isInt: true, i: v.Integer}, nil
+	case *pb.Number_Real:
+		if math.IsNaN(v.Real) || math.IsInf(v.Real, 0) {
+			return operand{}, invalidArgument(field, fmt.Sprintf("must be a finite number, got %v", v.Real))
+		}
+		return operand{f: v.Real}, nil
+	case *pb.Number_Decimal:
+		d, err := parseDecimal(field, v.Decimal)
+		if err != nil {
+			return operand{}, err
+		}
+		return operand{d: d}, nil
+	}
+
+	return operand{}, invalidArgument(field, "is required")
+}
+
+func readOperands(in *pb.BinaryOperationRequest) (operand, operand, error) {
+	a, err := readOperand("first", in.GetFirst())
+	if err != nil {
+		return operand{}, operand{}, err
+	}
+
+	b, err := readOperand("second", in.GetSecond())
+	if err != nil {
+		return operand{}, operand{}, err
+	}
+
+	return a, b, nil
+}
+
+// readInteger reads an operand of an integer-only operation,
+// accepting reals and decimals without a fractional part
+func readInteger(field string, n *pb.Number) (operand, error) {
+	o, err := readOperand(field, n)
+	if err != nil {
+		return operand{}, err
+	}
+
+	switch {
+	case o.isInt:
+		return o, nil
+	case o.d != nil:
+		if !o.d.IsInt() {
+			return operand{}, invalidArgument(field, fmt.Sprintf("must be an integer, got %s", formatDecimal(o.d)))
+		}
+		return o, nil
+	}
+
+	if o.f != math.Trunc(o.f) || o.f < math.MinInt64 || o.f >= math.MaxInt64 {
+		return operand{}, invalidArgument(field, fmt.Sprintf("must be an integer, got %v", o.f))
+	}
+
+	return operand{isInt: true, i: int64(o.f)}, nil
+}
+
+func readIntegers(in *pb.BinaryOperationRequest) (operand, operand, error) {
+	a, err := readInteger("first", in.GetFirst())
+	if err != nil {
+		return operand{}, operand{}, err
+	}
+
+	b, err := readInteger("second", in.GetSecond())
+	if err != nil {
+		return operand{}, operand{}, err
+	}
+
+	return a, b, nil
+}
+
+func integerResult(i int64) *pb.NumberResponse {
+	return &pb.NumberResponse{
+		Result: &pb.Number{Value: &pb.Number_Integer{Integer: i}},
+	}
+}
+
+func decimalResult(r *big.Rat) *pb.NumberResponse {
+	return &pb.NumberResponse{
+		Result: &pb.Number{Value: &pb.Number_Decimal{Decimal: formatDecimal(r)}},
+	}
+}
+
+func realResult(op string, f float64) (*pb.NumberResponse, error) {
+	if math.IsInf(f, 0) {
+		return nil, overflow(op)
+	}
+	if math.IsNaN(f) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			fmt.Sprintf("The result of %s is not a real number", op),
+		)
+	}
+
+	return &pb.NumberResponse{
+		Result: &pb.Number{Value: &pb.Number_Real{Real: f}},
+	}, nil
+}
+
+func overflow(op string) error {
+	return status.Errorf(
+		codes.OutOfRange,
+		fmt.Sprintf("The result of %s does not fit in a Number", op),
+	)
+}
+
+// mulInt64 multiplies a and b, reporting false when the product overflows
+func mulInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	p := a * b
+	if p/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+
+	return p, true
+}
+
+// powInt64 raises base to a non-negative exp by squaring,
+// reporting false when the result overflows
+func powInt64(base, exp int64) (int64, bool) {
+	result := int64(1)
+	for exp > 0 {
+		var ok bool
+		if exp&1 == 1 {
+			if result, ok = mulInt64(result, base); !ok {
+				return 0, false
+			}
+		}
+
+		// squaring is only needed, and its overflow only matters,
+		// while higher bits of exp are left
+		if exp >>= 1; exp > 0 {
+			if base, ok = mulInt64(base, base); !ok {
+				return 0, false
+			}
+		}
+	}
+
+	return result, true
+}
+
+// abs64 returns |x|, which for math.MinInt64 only fits in a uint64
+func abs64(x int64) uint64 {
+	if x < 0 {
+		return uint64(-x)
+	}
+
+	return uint64(x)
+}
+
+func gcd(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
+
+// Add implements calculator.CalculatorServiceServer
+func (s *server) Add(ctx context.Context, in *pb.BinaryOperationRequest) (*pb.NumberResponse, error) {
+	a, b, err := readOperands(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if decimals(a, b) {
+		return decimalResult(new(big.Rat).Add(a.rat(), b.rat())), nil
+	}
+
+	if a.isInt && b.isInt {
+		sum := a.i + b.i
+		if (b.i > 0 && sum < a.i) || (b.i < 0 && sum > a.i) {
+			return nil, overflow("Add")
+		}
+		return integerResult(sum), nil
+	}
+
+	return realResult("Add", a.float()+b.float())
+}
+
+// Subtract implements calculator.CalculatorServiceServer
+func (s *server) Subtract(ctx context.Context, in *pb.BinaryOperationRequest) (*pb.NumberResponse, error) {
+	a, b, err := readOperands(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if decimals(a, b) {
+		return decimalResult(new(big.Rat).Sub(a.rat(), b.rat())), nil
+	}
+
+	if a.isInt && b.isInt {
+		diff := a.i - b.i
+		if (b.i > 0 && diff > a.i) || (b.i < 0 && diff < a.i) {
+			return nil, overflow("Subtract")
+		}
+		return integerResult(diff), nil
+	}
+
+	return realResult("Subtract", a.float()-b.float())
+}
+
+// Multiply implements calculator.CalculatorServiceServer
+func (s *server) Multiply(ctx context.Context, in *pb.BinaryOperationRequest) (*pb.NumberResponse, error) {
+	a, b, err := readOperands(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if decimals(a, b) {
+		return decimalResult(new(big.Rat).Mul(a.rat(), b.rat())), nil
+	}
+
+	if a.isInt && b.isInt {
+		p, ok := mulInt64(a.i, b.i)
+		if !ok {
+			return nil, overflow("Multiply")
+		}
+		return integerResult(p), nil
+	}
+
+	return realResult("Multiply", a.float()*b.float())
+}
+
+// Divide implements calculator.CalculatorServiceServer
+func (s *server) Divide(ctx context.Context, in *pb.BinaryOperationRequest) (*pb.NumberResponse, error) {
+	a, b, err := readOperands(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.isZero() {
+		return nil, invalidArgument("second", "cannot divide by zero")
+	}
+
+	if decimals(a, b) {
+		return decimalResult(new(big.Rat).Quo(a.rat(), b.rat())), nil
+	}
+
+	if a.isInt && b.isInt {
+		if a.i == math.MinInt64 && b.i == -1 {
+			return nil, overflow("Divide")
+		}
+		if a.i%b.i == 0 {
+			return integerResult(a.i / b.i), nil
+		}
+	}
+
+	return realResult("Divide", a.float()/b.float())
+}
+
+// Modulo implements calculator.CalculatorServiceServer
+func (s *server) Modulo(ctx context.Context, in *pb.BinaryOperationRequest) (*pb.NumberResponse, error) {
+	a, b, err := readOperands(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.isZero() {
+		return nil, invalidArgument("second", "cannot divide by zero")
+	}
+
+	if decimals(a, b) {
+		// a - b*trunc(a/b), which has the sign of a like Go's %
+		q := new(big.Rat).Quo(a.rat(), b.rat())
+		trunc := new(big.Rat).SetInt(new(big.Int).Quo(q.Num(), q.Denom()))
+		return decimalResult(new(big.Rat).Sub(a.rat(), trunc.Mul(trunc, b.rat()))), nil
+	}
+
+	if a.isInt && b.isInt {
+		return integerResult(a.i % b.i), nil
+	}
+
+	return realResult("Modulo", math.Mod(a.float(), b.float()))
+}
+
+// Power implements calculator.CalculatorServiceServer
+func (s *server) Power(ctx context.Context, in *pb.BinaryOperationRequest) (*pb.NumberResponse, error) {
+	a, b, err := readOperands(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if decimals(a, b) {
+		return decimalPower(a, b)
+	}
+
+	if a.isInt && b.isInt && b.i >= 0 {
+		p, ok := powInt64(a.i, b.i)
+		if !ok {
+			return nil, overflow("Power")
+		}
+		return integerResult(p), nil
+	}
+
+	if a.isZero() && b.float() < 0 {
+		return nil, invalidArgument("second", "cannot raise zero to a negative power")
+	}
+
+	p := math.Pow(a.float(), b.float())
+	if math.IsNaN(p) {
+		return nil, invalidArgument("second", "must be an integer when first is negative")
+	}
+
+	return realResult("Power", p)
+}
+
+// decimalPower raises a to the integer power b exactly
+func decimalPower(a, b operand) (*pb.NumberResponse, error) {
+	exp := b.rat()
+	if !exp.IsInt() {
+		return nil, invalidArgument("second", "must be an integer when either operand is a decimal")
+	}
+
+	base := a.rat()
+	if base.Sign() == 0 && exp.Sign() < 0 {
+		return nil, invalidArgument("second", "cannot raise zero to a negative power")
+	}
+
+	// the result takes about bits*|exp| bits, and 0, 1 and -1 none at all
+	e := new(big.Int).Abs(exp.Num())
+	if bits := int64(base.Num().BitLen() + base.Denom().BitLen() - 2); bits > 0 {
+		if !e.IsInt64() || e.Int64() > maxPowerBits/bits {
+			return nil, overflow("Power")
+		}
+	}
+
+	p := new(big.Rat).SetFrac(
+		new(big.Int).Exp(base.Num(), e, nil),
+		new(big.Int).Exp(base.Denom(), e, nil),
+	)
+	if exp.Sign() < 0 {
+		p.Inv(p)
+	}
+
+	return decimalResult(p), nil
+}
+
+// GCD implements calculator.CalculatorServiceServer
+func (s *server) GCD(ctx context.Context, in *pb.BinaryOperationRequest) (*pb.NumberResponse, error) {
+	a, b, err := readIntegers(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if decimals(a, b) {
+		g := new(big.Int).GCD(nil, nil, new(big.Int).Abs(a.rat().Num()), new(big.Int).Abs(b.rat().Num()))
+		return decimalResult(new(big.Rat).SetInt(g)), nil
+	}
+
+	g := gcd(abs64(a.i), abs64(b.i))
+	if g > math.MaxInt64 {
+		return nil, overflow("GCD")
+	}
+
+	return integerResult(int64(g)), nil
+}
+
+// LCM implements calculator.CalculatorServiceServer
+func (s *server) LCM(ctx context.Context, in *pb.BinaryOperationRequest) (*pb.NumberResponse, error) {
+	a, b, err := readIntegers(in)
+	if err != nil {
+		return nil, err
+	}
+
+	if decimals(a, b) {
+		x, y := new(big.Int).Abs(a.rat().Num()), new(big.Int).Abs(b.rat().Num())
+		if x.Sign() == 0 || y.Sign() == 0 {
+			return decimalResult(new(big.Rat)), nil
+		}
+		l := new(big.Int).Mul(new(big.Int).Quo(x, new(big.Int).GCD(nil, nil, x, y)), y)
+		return decimalResult(new(big.Rat).SetInt(l)), nil
+	}
+
+	if a.i == 0 || b.i == 0 {
+		return integerResult(0), nil
+	}
+
+	ua, ub := abs64(a.i), abs64(b.i)
+	hi, l := bits.Mul64(ua/gcd(ua, ub), ub)
+	if hi != 0 || l > math.MaxInt64 {
+		return nil, overflow("LCM")
+	}
+
+	return integerResult(int64(l)), nil
+}
+
+// Factorial implements calculator.CalculatorServiceServer
+func (s *server) Factorial(ctx context.Context, in *pb.FactorialRequest) (*pb.FactorialResponse, error) {
+	o, err := readInteger("number", in.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	n := o.rat().Num()
+	if n.Sign() < 0 {
+		return nil, invalidArgument("number", fmt.Sprintf("must not be negative, got %s", n))
+	}
+	if n.Cmp(big.NewInt(maxFactorial)) > 0 {
+		return nil, invalidArgument("number", fmt.Sprintf("must be at most %d, got %s", maxFactorial, n))
+	}
+
+	return &pb.FactorialResponse{
+		Result: new(big.Int).MulRange(1, n.Int64()).String(),
+	}, nil
+}
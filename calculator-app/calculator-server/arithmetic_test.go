@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestArithmetic(t *testing.T) {
+	s := newServer(defaultConfig())
+	ops := map[string]func(context.Context, *pb.BinaryOperationRequest) (*pb.NumberResponse, error){
+		"Add":      s.Add,
+		"Subtract": s.Subtract,
+		"Multiply": s.Multiply,
+		"Divide":   s.Divide,
+		"Modulo":   s.Modulo,
+		"Power":    s.Power,
+		"GCD":      s.GCD,
+		"LCM":      s.LCM,
+	}
+
+	tests := []struct {
+		op   string
+		a, b *pb.Number
+		want *pb.Number
+		code codes.Code
+	}{
+		{op: "Add", a: integer(2), b: integer(3), want: integer(5)},
+		{op: "Add", a: integer(math.MaxInt64), b: integer(1), code: codes.OutOfRange},
+		{op: "Add", a: integer(math.MinInt64), b: integer(-1), code: codes.OutOfRange},
+		{op: "Add", a: integer(1), b: realNumber(0.5), want: realNumber(1.5)},
+		{op: "Add", a: realNumber(math.MaxFloat64), b: realNumber(math.MaxFloat64), code: codes.OutOfRange},
+		{op: "Add", a: realNumber(math.NaN()), b: integer(1), code: codes.InvalidArgument},
+		{op: "Add", a: integer(1), code: codes.InvalidArgument},
+		// negating MinInt64 overflows
+		{op: "Subtract", a: integer(0), b: integer(math.MinInt64), code: codes.OutOfRange},
+		{op: "Subtract", a: integer(-1), b: integer(math.MinInt64), want: integer(math.MaxInt64)},
+		{op: "Subtract", a: integer(math.MaxInt64), b: integer(-1), code: codes.OutOfRange},
+		{op: "Multiply", a: integer(math.MinInt64), b: integer(-1), code: codes.OutOfRange},
+		{op: "Multiply", a: integer(-1), b: integer(math.MinInt64), code: codes.OutOfRange},
+		{op: "Multiply", a: integer(1 << 32), b: integer(1 << 31), code: codes.OutOfRange},
+		{op: "Multiply", a: integer(1 << 31), b: integer(-1 << 32), want: integer(math.MinInt64)},
+		{op: "Divide", a: integer(math.MinInt64), b: integer(-1), code: codes.OutOfRange},
+		{op: "Divide", a: integer(math.MinInt64), b: integer(1), want: integer(math.MinInt64)},
+		{op: "Divide", a: integer(6), b: integer(3), want: integer(2)},
+		{op: "Divide", a: integer(7), b: integer(2), want: realNumber(3.5)},
+		{op: "Divide", a: integer(1), b: integer(0), code: codes.InvalidArgument},
+		{op: "Divide", a: integer(1), b: realNumber(0), code: codes.InvalidArgument},
+		{op: "Modulo", a: integer(math.MinInt64), b: integer(-1), want: integer(0)},
+		{op: "Modulo", a: integer(-7), b: integer(3), want: integer(-1)},
+		{op: "Modulo", a: realNumber(7.5), b: integer(2), want: realNumber(1.5)},
+		{op: "Modulo", a: integer(1), b: integer(0), code: codes.InvalidArgument},
+		{op: "Power", a: integer(2), b: integer(62), want: integer(1 << 62)},
+		{op: "Power", a: integer(2), b: integer(63), code: codes.OutOfRange},
+		{op: "Power", a: integer(-2), b: integer(63), want: integer(math.MinInt64)},
+		{op: "Power", a: integer(3), b: integer(39), want: integer(4052555153018976267)},
+		{op: "Power", a: integer(3), b: integer(40), code: codes.OutOfRange},
+		{op: "Power", a: integer(2), b: integer(-1), want: realNumber(0.5)},
+		{op: "Power", a: integer(0), b: integer(-1), code: codes.InvalidArgument},
+		{op: "Power", a: integer(-8), b: realNumber(1.0 / 3), code: codes.InvalidArgument},
+		{op: "Power", a: realNumber(10), b: integer(400), code: codes.OutOfRange},
+		{op: "GCD", a: integer(12), b: integer(-18), want: integer(6)},
+		{op: "GCD", a: integer(0), b: integer(0), want: integer(0)},
+		// |MinInt64| does not fit in an int64
+		{op: "GCD", a: integer(math.MinInt64), b: integer(0), code: codes.OutOfRange},
+		{op: "GCD", a: integer(math.MinInt64), b: integer(math.MinInt64), code: codes.OutOfRange},
+		{op: "GCD", a: integer(math.MinInt64), b: integer(6), want: integer(2)},
+		{op: "GCD", a: realNumber(12), b: integer(8), want: integer(4)},
+		{op: "GCD", a: realNumber(1.5), b: integer(8), code: codes.InvalidArgument},
+		{op: "LCM", a: integer(4), b: integer(-6), want: integer(12)},
+		{op: "LCM", a: integer(math.MinInt64), b: integer(0), want: integer(0)},
+		{op: "LCM", a: integer(math.MinInt64), b: integer(1), code: codes.OutOfRange},
+		{op: "LCM", a: integer(math.MaxInt64), b: integer(2), code: codes.OutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.op, func(t *testing.T) {
+			resp, err := ops[tt.op](context.Background(), &pb.BinaryOperationRequest{First: tt.a, Second: tt.b})
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("%s(%v, %v): got %v, want %v", tt.op, tt.a, tt.b, err, tt.code)
+			}
+			if tt.code == codes.OK && !proto.Equal(resp.GetResult(), tt.want) {
+				t.Errorf("%s(%v, %v) = %v, want %v", tt.op, tt.a, tt.b, resp.GetResult(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFactorial(t *testing.T) {
+	s := newServer(defaultConfig())
+
+	tests := []struct {
+		name string
+		n    *pb.Number
+		want string
+		code codes.Code
+	}{
+		{name: "zero", n: integer(0), want: "1"},
+		{name: "one", n: integer(1), want: "1"},
+		{name: "past int64", n: integer(21), want: "51090942171709440000"},
+		{name: "whole real", n: realNumber(5), want: "120"},
+		{name: "negative", n: integer(-1), code: codes.InvalidArgument},
+		{name: "MinInt64", n: integer(math.MinInt64), code: codes.InvalidArgument},
+		{name: "too large", n: integer(maxFactorial + 1), code: codes.InvalidArgument},
+		{name: "fraction", n: realNumber(2.5), code: codes.InvalidArgument},
+		{name: "missing", code: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.Factorial(context.Background(), &pb.FactorialRequest{Number: tt.n})
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got %v, want %v", err, tt.code)
+			}
+			if tt.code == codes.OK && resp.GetResult() != tt.want {
+				t.Errorf("%v! = %s, want %s", tt.n, resp.GetResult(), tt.want)
+			}
+		})
+	}
+
+	resp, err := s.Factorial(context.Background(), &pb.FactorialRequest{Number: integer(maxFactorial)})
+	if err != nil {
+		t.Fatalf("Factorial(%d): %v", maxFactorial, err)
+	}
+	if len(resp.GetResult()) != 35660 {
+		t.Errorf("%d! has %d digits, want 35660", maxFactorial, len(resp.GetResult()))
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"github.com/serhii12/grpc-go/streamerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxDecimalLength bounds the size of the numbers Big* RPCs accept,
+// so one request cannot make the server do unbounded work
+const maxDecimalLength = 1000
+
+// inexactScale is how many decimal places a result without a finite
+// decimal expansion, such as 1/3, is rounded to
+const inexactScale = 30
+
+// decimalPattern accepts plain decimals; exponents are left out on purpose,
+// as 1e999999999 would be short to send and huge to expand
+var decimalPattern = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`)
+
+// parseDecimal reads a Big* request field
+func parseDecimal(field, s string) (*big.Rat, error) {
+	if len(s) > maxDecimalLength {
+		return nil, invalidArgument(field, fmt.Sprintf("must be at most %d characters long", maxDecimalLength))
+	}
+	if !decimalPattern.MatchString(s) {
+		return nil, invalidArgument(field, fmt.Sprintf("%q is not a decimal number", s))
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, invalidArgument(field, fmt.Sprintf("%q is not a decimal number", s))
+	}
+
+	return r, nil
+}
+
+// formatDecimal writes r exactly when its decimal expansion is finite
+// and rounded to inexactScale places otherwise
+func formatDecimal(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	// a reduced fraction has a finite expansion when its denominator is
+	// 2^a * 5^b, and then needs max(a, b) decimal places
+	d := new(big.Int).Set(r.Denom())
+	twos, fives := 0, 0
+	for q, m := new(big.Int), new(big.Int); ; twos++ {
+		if q.DivMod(d, big.NewInt(2), m); m.Sign() != 0 {
+			break
+		}
+		d.Set(q)
+	}
+	for q, m := new(big.Int), new(big.Int); ; fives++ {
+		if q.DivMod(d, big.NewInt(5), m); m.Sign() != 0 {
+			break
+		}
+		d.Set(q)
+	}
+
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return r.FloatString(inexactScale)
+	}
+	if twos > fives {
+		return r.FloatString(twos)
+	}
+	return r.FloatString(fives)
+}
+
+// BigSum implements calculator.CalculatorServiceServer
+func (s *server) BigSum(ctx context.Context, in *pb.BigSumRequest) (*pb.BigSumResponse, error) {
+	a, err := parseDecimal("first_number", in.GetFirstNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := parseDecimal("second_number", in.GetSecondNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.BigSumResponse{
+		SumResult: formatDecimal(a.Add(a, b)),
+	}, nil
+}
+
+// BigComputeAverage implements calculator.CalculatorServiceServer
+func (s *server) BigComputeAverage(stream pb.CalculatorService_BigComputeAverageServer) error {
+	sum := new(big.Rat)
+	var count int64
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return streamerr.Status(err, "read the client stream")
+		}
+
+		n, err := parseDecimal("number", req.GetNumber())
+		if err != nil {
+			return err
+		}
+
+		sum.Add(sum, n)
+		count++
+	}
+
+	if count == 0 {
+		return status.Error(codes.InvalidArgument, "Cannot average an empty stream")
+	}
+
+	average := sum.Quo(sum, new(big.Rat).SetInt64(count))
+	return stream.SendAndClose(&pb.BigComputeAverageResponse{
+		Average: formatDecimal(average),
+	})
+}
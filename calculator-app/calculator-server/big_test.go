@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSum(t *testing.T) {
+	s := newServer(defaultConfig())
+
+	tests := []struct {
+		name   string
+		a, b   int32
+		want   int32
+		wantOK bool
+	}{
+		{name: "small", a: 3, b: 10, want: 13, wantOK: true},
+		{name: "up to the max", a: math.MaxInt32 - 1, b: 1, want: math.MaxInt32, wantOK: true},
+		{name: "down to the min", a: math.MinInt32 + 1, b: -1, want: math.MinInt32, wantOK: true},
+		{name: "past the max", a: math.MaxInt32, b: 1},
+		{name: "past the min", a: math.MinInt32, b: -1},
+		{name: "both at the max", a: math.MaxInt32, b: math.MaxInt32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.Sum(context.Background(), &pb.SumRequest{FirstNumber: tt.a, SecondNumber: tt.b})
+			if !tt.wantOK {
+				if status.Code(err) != codes.OutOfRange {
+					t.Fatalf("got %v, %v, want OutOfRange", resp, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Sum: %v", err)
+			}
+			if resp.GetSumResult() != tt.want {
+				t.Errorf("sum = %d, want %d", resp.GetSumResult(), tt.want)
+			}
+		})
+	}
+}
+
+func TestBigSum(t *testing.T) {
+	s := newServer(defaultConfig())
+
+	tests := []struct {
+		name      string
+		a, b      string
+		want      string
+		wantField string
+	}{
+		{name: "past int64", a: "9223372036854775807", b: "1", want: "9223372036854775808"},
+		{name: "past int32", a: "2147483647", b: "2147483647", want: "4294967294"},
+		{name: "decimals", a: "0.1", b: "0.2", want: "0.3"},
+		{name: "trailing zeros are dropped", a: "1.50", b: "1.50", want: "3"},
+		{name: "signs", a: "-12.5", b: "+2.25", want: "-10.25"},
+		{name: "huge", a: "1" + strings.Repeat("0", 99), b: "-1", want: strings.Repeat("9", 99)},
+		{name: "empty", a: "", b: "1", wantField: "first_number"},
+		{name: "exponent", a: "1", b: "1e9", wantField: "second_number"},
+		{name: "not a number", a: "one", b: "1", wantField: "first_number"},
+		{name: "too long", a: "1", b: strings.Repeat("9", maxDecimalLength+1), wantField: "second_number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.BigSum(context.Background(), &pb.BigSumRequest{FirstNumber: tt.a, SecondNumber: tt.b})
+			if tt.wantField != "" {
+				if field := violatedField(t, err); field != tt.wantField {
+					t.Errorf("violated field = %q, want %q", field, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BigSum: %v", err)
+			}
+			if resp.GetSumResult() != tt.want {
+				t.Errorf("sum = %s, want %s", resp.GetSumResult(), tt.want)
+			}
+		})
+	}
+}
+
+func TestBigComputeAverage(t *testing.T) {
+	c, _ := startServer(t)
+
+	tests := []struct {
+		name    string
+		numbers []string
+		want    string
+		code    codes.Code
+	}{
+		{name: "exact", numbers: []string{"1", "2", "3", "4"}, want: "2.5"},
+		{name: "past int64", numbers: []string{"9223372036854775807", "9223372036854775807"}, want: "9223372036854775807"},
+		{name: "rounded", numbers: []string{"1", "0", "0"}, want: "0." + strings.Repeat("3", inexactScale)},
+		{name: "negative", numbers: []string{"-1.5", "0.5"}, want: "-0.5"},
+		{name: "empty", code: codes.InvalidArgument},
+		{name: "invalid number", numbers: []string{"1", "x"}, code: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := c.BigComputeAverage(context.Background())
+			if err != nil {
+				t.Fatalf("BigComputeAverage: %v", err)
+			}
+			for _, n := range tt.numbers {
+				if err := stream.Send(&pb.BigComputeAverageRequest{Number: n}); err != nil {
+					break
+				}
+			}
+
+			resp, err := stream.CloseAndRecv()
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got %v, want %v", err, tt.code)
+			}
+			if tt.code == codes.OK && resp.GetAverage() != tt.want {
+				t.Errorf("average = %s, want %s", resp.GetAverage(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "12", want: "12"},
+		{in: "1/8", want: "0.125"},
+		{in: "-7/20", want: "-0.35"},
+		{in: "1/1024", want: "0.0009765625"},
+		{in: "2/3", want: "0." + strings.Repeat("6", inexactScale-1) + "7"},
+		{in: "1/6", want: "0.1" + strings.Repeat("6", inexactScale-2) + "7"},
+	}
+
+	for _, tt := range tests {
+		r, _ := new(big.Rat).SetString(tt.in)
+		if got := formatDecimal(r); got != tt.want {
+			t.Errorf("formatDecimal(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func integer(i int64) *pb.Number {
+	return &pb.Number{Value: &pb.Number_Integer{Integer: i}}
+}
+
+func realNumber(f float64) *pb.Number {
+	return &pb.Number{Value: &pb.Number_Real{Real: f}}
+}
+
+func decimal(s string) *pb.Number {
+	return &pb.Number{Value: &pb.Number_Decimal{Decimal: s}}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	s := newServer(defaultConfig())
+	ops := map[string]func(context.Context, *pb.BinaryOperationRequest) (*pb.NumberResponse, error){
+		"Add":      s.Add,
+		"Subtract": s.Subtract,
+		"Multiply": s.Multiply,
+		"Divide":   s.Divide,
+		"Modulo":   s.Modulo,
+		"Power":    s.Power,
+		"GCD":      s.GCD,
+		"LCM":      s.LCM,
+	}
+
+	tests := []struct {
+		op   string
+		a, b *pb.Number
+		want string
+		code codes.Code
+	}{
+		{op: "Add", a: decimal("9223372036854775807"), b: integer(1), want: "9223372036854775808"},
+		{op: "Add", a: decimal("0.1"), b: realNumber(0.2), want: "0.3"},
+		{op: "Subtract", a: integer(math.MinInt64), b: decimal("1"), want: "-9223372036854775809"},
+		{op: "Multiply", a: decimal("4294967296"), b: decimal("4294967296"), want: "18446744073709551616"},
+		{op: "Multiply", a: decimal("1.5"), b: decimal("-0.2"), want: "-0.3"},
+		{op: "Divide", a: decimal("1"), b: integer(4), want: "0.25"},
+		{op: "Divide", a: decimal("2"), b: integer(3), want: "0." + strings.Repeat("6", inexactScale-1) + "7"},
+		{op: "Divide", a: decimal("1"), b: decimal("0.000"), code: codes.InvalidArgument},
+		{op: "Modulo", a: decimal("7.5"), b: integer(2), want: "1.5"},
+		{op: "Modulo", a: decimal("-7.5"), b: integer(2), want: "-1.5"},
+		{op: "Modulo", a: decimal("100000000000000000000"), b: integer(7), want: "2"},
+		{op: "Power", a: decimal("2"), b: integer(64), want: "18446744073709551616"},
+		{op: "Power", a: decimal("0.5"), b: integer(3), want: "0.125"},
+		{op: "Power", a: decimal("2"), b: integer(-2), want: "0.25"},
+		{op: "Power", a: decimal("1"), b: integer(math.MaxInt64), want: "1"},
+		{op: "Power", a: decimal("0"), b: integer(-1), code: codes.InvalidArgument},
+		{op: "Power", a: decimal("2"), b: realNumber(0.5), code: codes.InvalidArgument},
+		{op: "Power", a: decimal("2"), b: integer(maxPowerBits + 1), code: codes.OutOfRange},
+		{op: "GCD", a: decimal("-36893488147419103232"), b: integer(48), want: "16"},
+		{op: "GCD", a: integer(math.MinInt64), b: decimal("0"), want: "9223372036854775808"},
+		{op: "GCD", a: decimal("1.5"), b: integer(3), code: codes.InvalidArgument},
+		{op: "LCM", a: decimal("9223372036854775807"), b: integer(2), want: "18446744073709551614"},
+		{op: "LCM", a: decimal("0"), b: integer(5), want: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.op, func(t *testing.T) {
+			resp, err := ops[tt.op](context.Background(), &pb.BinaryOperationRequest{First: tt.a, Second: tt.b})
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("%s(%v, %v): got %v, want %v", tt.op, tt.a, tt.b, err, tt.code)
+			}
+			if tt.code != codes.OK {
+				return
+			}
+			if got := resp.GetResult().GetDecimal(); got != tt.want {
+				t.Errorf("%s(%v, %v) = %v, want decimal %s", tt.op, tt.a, tt.b, resp.GetResult(), tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalFactorial(t *testing.T) {
+	s := newServer(defaultConfig())
+
+	resp, err := s.Factorial(context.Background(), &pb.FactorialRequest{Number: decimal("20")})
+	if err != nil {
+		t.Fatalf("Factorial: %v", err)
+	}
+	if resp.GetResult() != "2432902008176640000" {
+		t.Errorf("20! = %s", resp.GetResult())
+	}
+
+	for _, n := range []string{"-1", "10001", "99999999999999999999", "-99999999999999999999", "2.5"} {
+		_, err := s.Factorial(context.Background(), &pb.FactorialRequest{Number: decimal(n)})
+		if field := violatedField(t, err); field != "number" {
+			t.Errorf("Factorial(%s): violated field = %q, want number", n, field)
+		}
+	}
+}
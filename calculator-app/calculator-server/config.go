@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/serhii12/grpc-go/config"
+)
+
+// squareRootConfig tunes the SquareRoot RPC
+type squareRootConfig struct {
+	Precision int `yaml:"precision" usage:"decimal places SquareRoot results are rounded to, 0 for full precision"`
+}
+
+// statisticsConfig tunes the ComputeStatistics RPC
+type statisticsConfig struct {
+	Percentiles []float64 `yaml:"percentiles"`
+}
+
+// aggregateConfig limits the windows of the RunningAggregate RPC
+type aggregateConfig struct {
+	MaxWindowCount    uint32        `yaml:"max_window_count" usage:"most numbers one RunningAggregate window may hold"`
+	MaxWindowDuration time.Duration `yaml:"max_window_duration" usage:"longest RunningAggregate time window"`
+}
+
+// matrixConfig limits the matrix and vector RPCs
+type matrixConfig struct {
+	MaxDimension int `yaml:"max_dimension" usage:"most rows and columns a matrix, and values a vector, may have"`
+}
+
+// primesConfig limits the prime RPCs
+type primesConfig struct {
+	MaxRange int64 `yaml:"max_range" usage:"most numbers one PrimesInRange call may cover"`
+}
+
+// runningAggregateMethod is the full method name of RunningAggregate, whose
+// streams must outlive their longest window
+const runningAggregateMethod = "/calculator.CalculatorService/RunningAggregate"
+
+// serverConfig is the calculator-server configuration, see package config
+type serverConfig struct {
+	Server    config.Server    `yaml:"server"`
+	Log       config.Log       `yaml:"log"`
+	Metrics   config.Metrics   `yaml:"metrics"`
+	Tracing   config.Tracing   `yaml:"tracing"`
+	RateLimit config.RateLimit `yaml:"rate_limit"`
+	Deadlines config.Deadlines `yaml:"deadlines"`
+
+	SquareRoot squareRootConfig `yaml:"square_root"`
+	Primes     primesConfig     `yaml:"primes"`
+	Statistics statisticsConfig `yaml:"statistics"`
+	Aggregate  aggregateConfig  `yaml:"running_aggregate"`
+	Matrix     matrixConfig     `yaml:"matrix"`
+}
+
+func defaultConfig() *serverConfig {
+	deadlines := config.DefaultDeadlines()
+	deadlines.Methods = map[string]config.MethodDeadline{
+		runningAggregateMethod: {Default: 2 * time.Hour, Max: 24 * time.Hour},
+	}
+
+	return &serverConfig{
+		Server:    config.DefaultServer(),
+		Log:       config.DefaultLog(),
+		Metrics:   config.DefaultMetrics(),
+		Tracing:   config.DefaultTracing(),
+		RateLimit: config.DefaultRateLimit(),
+		Deadlines: deadlines,
+
+		Primes:     primesConfig{MaxRange: 10000000},
+		Statistics: statisticsConfig{Percentiles: []float64{25, 75, 90, 95, 99}},
+		Aggregate:  aggregateConfig{MaxWindowCount: 100000, MaxWindowDuration: time.Hour},
+		Matrix:     matrixConfig{MaxDimension: 500},
+	}
+}
+
+func (c *serverConfig) Validate() error {
+	if err := c.Server.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Log.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Metrics.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Tracing.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.RateLimit.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Deadlines.Validate(); err != nil {
+		return err
+	}
+
+	// float64 holds about 15 significant decimal digits
+	if c.SquareRoot.Precision < 0 || c.SquareRoot.Precision > 15 {
+		return fmt.Errorf("square_root.precision: must be between 0 and 15")
+	}
+
+	if c.Primes.MaxRange < 1 {
+		return fmt.Errorf("primes.max_range: must be positive")
+	}
+
+	for _, p := range c.Statistics.Percentiles {
+		if !(p >= 0 && p <= 100) {
+			return fmt.Errorf("statistics.percentiles: must be between 0 and 100, got %v", p)
+		}
+	}
+
+	if c.Aggregate.MaxWindowCount < 1 {
+		return fmt.Errorf("running_aggregate.max_window_count: must be positive")
+	}
+
+	if c.Aggregate.MaxWindowDuration < time.Millisecond {
+		return fmt.Errorf("running_aggregate.max_window_duration: must be at least 1ms")
+	}
+
+	// a stream cut off by its deadline never completes its longest window
+	limit := c.Deadlines.For(runningAggregateMethod)
+	if (limit.Default > 0 && limit.Default < c.Aggregate.MaxWindowDuration) || (limit.Max > 0 && limit.Max < c.Aggregate.MaxWindowDuration) {
+		return fmt.Errorf("running_aggregate.max_window_duration: must not exceed the default or max deadline of %s", runningAggregateMethod)
+	}
+
+	if c.Matrix.MaxDimension < 1 {
+		return fmt.Errorf("matrix.max_dimension: must be positive")
+	}
+
+	return nil
+}
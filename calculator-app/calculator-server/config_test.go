@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/serhii12/grpc-go/config"
+)
+
+func TestDefaultConfigIsValid(t *testing.T) {
+	if err := defaultConfig().Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
+
+func TestValidateWindowFitsTheAggregateDeadline(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   *config.MethodDeadline
+		wantErr bool
+	}{
+		{name: "within default and max", limit: &config.MethodDeadline{Default: 2 * time.Hour, Max: 24 * time.Hour}},
+		{name: "no deadlines", limit: &config.MethodDeadline{}},
+		{name: "longer than the default", limit: &config.MethodDeadline{Default: time.Minute, Max: 24 * time.Hour}, wantErr: true},
+		{name: "longer than the max", limit: &config.MethodDeadline{Max: 30 * time.Minute}, wantErr: true},
+		// without an entry of its own the method gets the global 30s default
+		{name: "global deadlines", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := defaultConfig()
+			delete(cfg.Deadlines.Methods, runningAggregateMethod)
+			if tt.limit != nil {
+				cfg.Deadlines.Methods[runningAggregateMethod] = *tt.limit
+			}
+
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.HasPrefix(err.Error(), "running_aggregate.max_window_duration") {
+				t.Errorf("got %v, want a running_aggregate.max_window_duration error", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,591 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Evaluate parses and evaluates infix expressions with this grammar,
+// where ^ binds tighter than unary minus, so -2^2 is -4:
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/" | "%") unary }
+//	unary   = ("+" | "-") unary | power
+//	power   = primary [ "^" unary ]
+//	primary = number | name | name "(" expr { "," expr } ")" | "(" expr ")"
+
+const (
+	// maxExpressionLength and maxExpressionDepth bound the work and the
+	// recursion one expression can cause
+	maxExpressionLength = 4096
+	maxExpressionDepth  = 100
+)
+
+// exprError is a problem with an expression, at a 1-based column
+type exprError struct {
+	column int
+	msg    string
+	code   codes.Code
+}
+
+func (e *exprError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.column, e.msg)
+}
+
+func invalidExpr(column int, format string, args ...interface{}) *exprError {
+	return &exprError{column: column, msg: fmt.Sprintf(format, args...), code: codes.InvalidArgument}
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenName
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	number float64
+	column int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+
+	return strconv.Quote(t.text)
+}
+
+func tokenize(src string) ([]token, error) {
+	runes := []rune(src)
+	var tokens []token
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i++
+			continue
+		case isDigit(r) || (r == '.' && i+1 < len(runes) && isDigit(runes[i+1])):
+			for i < len(runes) && isDigit(runes[i]) {
+				i++
+			}
+			if i < len(runes) && runes[i] == '.' {
+				i++
+				for i < len(runes) && isDigit(runes[i]) {
+					i++
+				}
+			}
+			// an exponent only counts when digits follow, so 2e is
+			// the number 2 followed by the name e
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				j := i + 1
+				if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+					j++
+				}
+				if j < len(runes) && isDigit(runes[j]) {
+					for i = j; i < len(runes) && isDigit(runes[i]); i++ {
+					}
+				}
+			}
+
+			text := string(runes[start:i])
+			n, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, invalidExpr(start+1, "number %s is out of range", text)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, number: n, column: start + 1})
+			continue
+		case unicode.IsLetter(r) || r == '_':
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || isDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenName, text: string(runes[start:i]), column: start + 1})
+			continue
+		}
+
+		kind := tokenOperator
+		switch r {
+		case '+', '-', '*', '/', '%', '^':
+		case '(':
+			kind = tokenLeftParen
+		case ')':
+			kind = tokenRightParen
+		case ',':
+			kind = tokenComma
+		default:
+			return nil, invalidExpr(start+1, "unexpected character %q", r)
+		}
+		tokens = append(tokens, token{kind: kind, text: string(r), column: start + 1})
+		i++
+	}
+
+	return append(tokens, token{kind: tokenEOF, column: len(runes) + 1}), nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// node is an expression tree node
+type node interface {
+	eval(vars map[string]float64) (float64, error)
+}
+
+type numberNode struct {
+	value float64
+}
+
+type nameNode struct {
+	name   string
+	column int
+}
+
+type unaryNode struct {
+	operand node
+}
+
+type binaryNode struct {
+	op          rune
+	left, right node
+	column      int
+}
+
+type callNode struct {
+	name   string
+	args   []node
+	column int
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+// parse turns src into an expression tree
+func parse(src string) (node, error) {
+	if len(src) > maxExpressionLength {
+		return nil, invalidExpr(1, "expression is longer than %d bytes", maxExpressionLength)
+	}
+
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	n, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, invalidExpr(t.column, "unexpected %v", t)
+	}
+
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *parser) isOperator(ops string) bool {
+	t := p.peek()
+	for _, op := range ops {
+		if t.kind == tokenOperator && t.text == string(op) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enter guards the recursion of nested parentheses, unary operators
+// and exponents
+func (p *parser) enter() error {
+	if p.depth++; p.depth > maxExpressionDepth {
+		return invalidExpr(p.peek().column, "expression is nested deeper than %d levels", maxExpressionDepth)
+	}
+
+	return nil
+}
+
+func (p *parser) expr() (node, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("+-") {
+		op := p.next()
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: rune(op.text[0]), left: left, right: right, column: op.column}
+	}
+
+	return left, nil
+}
+
+func (p *parser) term() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOperator("*/%") {
+		op := p.next()
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: rune(op.text[0]), left: left, right: right, column: op.column}
+	}
+
+	return left, nil
+}
+
+func (p *parser) unary() (node, error) {
+	if !p.isOperator("+-") {
+		return p.power()
+	}
+
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+
+	op := p.next()
+	operand, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	if op.text == "+" {
+		return operand, nil
+	}
+
+	return &unaryNode{operand: operand}, nil
+}
+
+func (p *parser) power() (node, error) {
+	base, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.isOperator("^") {
+		return base, nil
+	}
+
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+
+	op := p.next()
+	// the exponent may carry its own sign and is right-associative: 2^-3^2 is 2^(-(3^2))
+	exponent, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &binaryNode{op: '^', left: base, right: exponent, column: op.column}, nil
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		return &numberNode{value: t.number}, nil
+	case tokenName:
+		if p.peek().kind != tokenLeftParen {
+			return &nameNode{name: t.text, column: t.column}, nil
+		}
+		return p.call(t)
+	case tokenLeftParen:
+		if err := p.enter(); err != nil {
+			return nil, err
+		}
+		defer func() { p.depth-- }()
+
+		n, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRightParen {
+			return nil, invalidExpr(closing.column, "expected \")\" to close the \"(\" at column %d, got %v", t.column, closing)
+		}
+		return n, nil
+	}
+
+	return nil, invalidExpr(t.column, "expected a number, name or \"(\", got %v", t)
+}
+
+func (p *parser) call(name token) (node, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer func() { p.depth-- }()
+
+	open := p.next()
+	c := &callNode{name: name.text, column: name.column}
+	for {
+		arg, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		c.args = append(c.args, arg)
+
+		switch t := p.next(); t.kind {
+		case tokenComma:
+			continue
+		case tokenRightParen:
+			return c, nil
+		default:
+			return nil, invalidExpr(t.column, "expected \",\" or \")\" to close the \"(\" at column %d, got %v", open.column, t)
+		}
+	}
+}
+
+// constants are the names defined in every expression,
+// unless the request has variables of the same name
+var constants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// function is a built-in function; an arity of -1 takes one or more arguments
+type function struct {
+	arity int
+	fn    func(args []float64) float64
+}
+
+func unaryFunc(f func(float64) float64) function {
+	return function{arity: 1, fn: func(args []float64) float64 { return f(args[0]) }}
+}
+
+// logFunc makes the poles of the logarithms at 0 undefined rather than infinite
+func logFunc(f func(float64) float64) function {
+	return unaryFunc(func(x float64) float64 {
+		if x <= 0 {
+			return math.NaN()
+		}
+		return f(x)
+	})
+}
+
+var functions = map[string]function{
+	"sqrt":  unaryFunc(math.Sqrt),
+	"abs":   unaryFunc(math.Abs),
+	"sin":   unaryFunc(math.Sin),
+	"cos":   unaryFunc(math.Cos),
+	"tan":   unaryFunc(math.Tan),
+	"asin":  unaryFunc(math.Asin),
+	"acos":  unaryFunc(math.Acos),
+	"atan":  unaryFunc(math.Atan),
+	"exp":   unaryFunc(math.Exp),
+	"ln":    logFunc(math.Log),
+	"log":   logFunc(math.Log10),
+	"log2":  logFunc(math.Log2),
+	"floor": unaryFunc(math.Floor),
+	"ceil":  unaryFunc(math.Ceil),
+	"round": unaryFunc(math.Round),
+	"min": {arity: -1, fn: func(args []float64) float64 {
+		m := args[0]
+		for _, a := range args[1:] {
+			m = math.Min(m, a)
+		}
+		return m
+	}},
+	"max": {arity: -1, fn: func(args []float64) float64 {
+		m := args[0]
+		for _, a := range args[1:] {
+			m = math.Max(m, a)
+		}
+		return m
+	}},
+}
+
+// checkResult rejects results that are not finite real numbers
+func checkResult(v float64, column int, what string) (float64, error) {
+	switch {
+	case math.IsNaN(v):
+		return 0, invalidExpr(column, "%s is not a real number", what)
+	case math.IsInf(v, 0):
+		return 0, &exprError{column: column, msg: fmt.Sprintf("%s is too large", what), code: codes.OutOfRange}
+	}
+
+	return v, nil
+}
+
+func (n *numberNode) eval(map[string]float64) (float64, error) {
+	return n.value, nil
+}
+
+func (n *nameNode) eval(vars map[string]float64) (float64, error) {
+	if v, ok := vars[n.name]; ok {
+		return v, nil
+	}
+	if v, ok := constants[n.name]; ok {
+		return v, nil
+	}
+	if _, ok := functions[n.name]; ok {
+		return 0, invalidExpr(n.column, "function %s needs arguments in parentheses", n.name)
+	}
+
+	return 0, invalidExpr(n.column, "unknown variable %q", n.name)
+}
+
+func (n *unaryNode) eval(vars map[string]float64) (float64, error) {
+	v, err := n.operand.eval(vars)
+	return -v, err
+}
+
+func (n *binaryNode) eval(vars map[string]float64) (float64, error) {
+	l, err := n.left.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+	r, err := n.right.eval(vars)
+	if err != nil {
+		return 0, err
+	}
+
+	var v float64
+	switch n.op {
+	case '+':
+		v = l + r
+	case '-':
+		v = l - r
+	case '*':
+		v = l * r
+	case '/', '%':
+		if r == 0 {
+			return 0, invalidExpr(n.column, "division by zero")
+		}
+		if n.op == '/' {
+			v = l / r
+		} else {
+			v = math.Mod(l, r)
+		}
+	case '^':
+		if l == 0 && r < 0 {
+			return 0, invalidExpr(n.column, "zero cannot be raised to a negative power")
+		}
+		v = math.Pow(l, r)
+	}
+
+	return checkResult(v, n.column, fmt.Sprintf("the result of %q", n.op))
+}
+
+func (n *callNode) eval(vars map[string]float64) (float64, error) {
+	f, ok := functions[n.name]
+	if !ok {
+		return 0, invalidExpr(n.column, "unknown function %q", n.name)
+	}
+
+	if f.arity >= 0 && len(n.args) != f.arity {
+		return 0, invalidExpr(n.column, "%s takes %d argument(s), got %d", n.name, f.arity, len(n.args))
+	}
+
+	args := make([]float64, len(n.args))
+	for i, a := range n.args {
+		v, err := a.eval(vars)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = v
+	}
+
+	shown := make([]string, len(args))
+	for i, a := range args {
+		shown[i] = strconv.FormatFloat(a, 'g', -1, 64)
+	}
+
+	return checkResult(f.fn(args), n.column, fmt.Sprintf("%s(%s)", n.name, strings.Join(shown, ", ")))
+}
+
+// expressionError reports err as a status with the offending column
+// in the error details
+func expressionError(cause error) error {
+	var err *exprError
+	if !errors.As(cause, &err) {
+		return status.Errorf(codes.Internal, fmt.Sprintf("Could not evaluate the expression: %v", cause))
+	}
+
+	st := status.New(err.code, fmt.Sprintf("Invalid expression: %v", err))
+
+	details := []proto.Message{
+		&errdetails.ErrorInfo{
+			Reason:   "INVALID_EXPRESSION",
+			Domain:   "calculator",
+			Metadata: map[string]string{"column": strconv.Itoa(err.column)},
+		},
+	}
+	if err.code == codes.InvalidArgument {
+		details = append(details, &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "expression", Description: err.Error()},
+			},
+		})
+	}
+
+	detailed, detailErr := st.WithDetails(details...)
+	if detailErr != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// Evaluate implements calculator.CalculatorServiceServer
+func (s *server) Evaluate(ctx context.Context, in *pb.EvaluateRequest) (*pb.EvaluateResponse, error) {
+	for name, v := range in.GetVariables() {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, invalidArgument("variables["+name+"]", fmt.Sprintf("must be a finite number, got %v", v))
+		}
+	}
+
+	tree, err := parse(in.GetExpression())
+	if err != nil {
+		return nil, expressionError(err)
+	}
+
+	result, err := tree.eval(in.GetVariables())
+	if err != nil {
+		return nil, expressionError(err)
+	}
+
+	return &pb.EvaluateResponse{
+		Result: result,
+	}, nil
+}
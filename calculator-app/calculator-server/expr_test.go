@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func evaluate(src string, vars map[string]float64) (float64, error) {
+	tree, err := parse(src)
+	if err != nil {
+		return 0, err
+	}
+
+	return tree.eval(vars)
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		expr string
+		vars map[string]float64
+		want float64
+	}{
+		// precedence and associativity
+		{expr: "1 + 2 * 3", want: 7},
+		{expr: "(1 + 2) * 3", want: 9},
+		{expr: "10 - 4 - 3", want: 3},
+		{expr: "100 / 10 / 5", want: 2},
+		{expr: "7 % 4 * 2", want: 6},
+		{expr: "1 + 2 ^ 3 * 2", want: 17},
+		{expr: "2 ^ 3 ^ 2", want: 512},
+		{expr: "2^-3^2", want: 1.0 / 512},
+		{expr: "2 ^ 0.5 ^ 2", want: math.Pow(2, 0.25)},
+		{expr: "(2 ^ 3) ^ 2", want: 64},
+
+		// unary operators
+		{expr: "-2^2", want: -4},
+		{expr: "(-2)^2", want: 4},
+		{expr: "--3", want: 3},
+		{expr: "-+-3", want: 3},
+		{expr: "- - -3", want: -3},
+		{expr: "2 * -3", want: -6},
+		{expr: "2 - -3", want: 5},
+		{expr: "2^-1", want: 0.5},
+
+		// numbers
+		{expr: "2e3", want: 2000},
+		{expr: ".5 + 1.5E-1", want: 0.65},
+
+		// functions
+		{expr: "sqrt(16) + abs(-2)", want: 6},
+		{expr: "max(1, 5, 3) - min(4, 2)", want: 3},
+		{expr: "max(7)", want: 7},
+		{expr: "round(2.5) + floor(-1.5) + ceil(1.2)", want: 3},
+		{expr: "log(1000) + log2(8) + ln(e)", want: 7},
+		{expr: "sin(pi / 2) + cos(0)", want: 2},
+		{expr: "sqrt(max(9, 3 * 3 + 7))", want: 4},
+
+		// variables and constants
+		{expr: "pi", want: math.Pi},
+		{expr: "x * y + z", vars: map[string]float64{"x": 2, "y": 3, "z": -1}, want: 5},
+		{expr: "e", vars: map[string]float64{"e": 2}, want: 2},
+		{expr: "_rate_2 * 100", vars: map[string]float64{"_rate_2": 0.25}, want: 25},
+
+		// nesting up to the limit
+		{expr: strings.Repeat("(", maxExpressionDepth) + "1" + strings.Repeat(")", maxExpressionDepth), want: 1},
+		{expr: strings.Repeat("-", maxExpressionDepth) + "1", want: 1},
+		{expr: "1" + strings.Repeat("^1", maxExpressionDepth), want: 1},
+	}
+
+	for _, tt := range tests {
+		got, err := evaluate(tt.expr, tt.vars)
+		if err != nil {
+			t.Errorf("%.40s: %v", tt.expr, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-12*math.Max(1, math.Abs(tt.want)) {
+			t.Errorf("%.40s = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		expr   string
+		column int
+		code   codes.Code
+	}{
+		{name: "unknown variable", expr: "1 + foo", column: 5},
+		{name: "function without arguments", expr: "2 * sqrt", column: 5},
+		{name: "unknown function", expr: "1 + nope(1)", column: 5},
+		{name: "wrong number of arguments", expr: "sqrt(1, 2)", column: 1},
+		{name: "unclosed paren", expr: "(1 + 2", column: 7},
+		{name: "unopened paren", expr: "1 + 2)", column: 6},
+		{name: "unclosed call", expr: "max(1, 2", column: 9},
+		{name: "empty parens", expr: "()", column: 2},
+		{name: "empty expression", expr: "", column: 1},
+		{name: "missing operand", expr: "1 +", column: 4},
+		{name: "two numbers", expr: "2 3", column: 3},
+		{name: "number followed by a name", expr: "2e", column: 2},
+		{name: "unexpected character", expr: "1 $ 2", column: 3},
+		{name: "number out of range", expr: "1 + 1e999", column: 5},
+		{name: "division by zero", expr: "1 + 4 / (2 - 2)", column: 7},
+		{name: "modulo by zero", expr: "5 % 0", column: 3},
+		{name: "zero to a negative power", expr: "0 ^ -1", column: 3},
+		{name: "not a real number", expr: "sqrt(-1)", column: 1},
+		{name: "logarithm of zero", expr: "ln(0)", column: 1},
+		{name: "overflow", expr: "1 + 10 ^ 400", column: 8, code: codes.OutOfRange},
+		{name: "too long", expr: strings.Repeat("1+", maxExpressionLength/2) + "1", column: 1},
+		{
+			name:   "parens too deep",
+			expr:   strings.Repeat("(", maxExpressionDepth+1) + "1" + strings.Repeat(")", maxExpressionDepth+1),
+			column: maxExpressionDepth + 2,
+		},
+		{name: "unary operators too deep", expr: strings.Repeat("-", maxExpressionDepth+1) + "1", column: maxExpressionDepth + 1},
+		{name: "exponents too deep", expr: "1" + strings.Repeat("^2", maxExpressionDepth+1), column: 2*maxExpressionDepth + 2},
+		{name: "calls too deep", expr: strings.Repeat("abs(", maxExpressionDepth+1) + "1" + strings.Repeat(")", maxExpressionDepth+1), column: 4*maxExpressionDepth + 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.code
+			if want == codes.OK {
+				want = codes.InvalidArgument
+			}
+
+			_, err := evaluate(tt.expr, nil)
+			var exprErr *exprError
+			if !errors.As(err, &exprErr) {
+				t.Fatalf("got %v, want an expression error", err)
+			}
+			if exprErr.column != tt.column || exprErr.code != want {
+				t.Errorf("got %v at column %d, want %v at column %d: %v", exprErr.code, exprErr.column, want, tt.column, exprErr)
+			}
+		})
+	}
+}
+
+func TestEvaluateReportsColumn(t *testing.T) {
+	s := newServer(defaultConfig())
+	_, err := s.Evaluate(context.Background(), &pb.EvaluateRequest{Expression: "1 / (x - 1)", Variables: map[string]float64{"x": 1}})
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("got %v, want InvalidArgument", err)
+	}
+
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			if got := info.GetMetadata()["column"]; got != "3" {
+				t.Errorf("column = %q, want 3", got)
+			}
+			return
+		}
+	}
+	t.Errorf("no ErrorInfo in %v", st.Details())
+}
+
+func TestExpressionErrorOfOtherErrors(t *testing.T) {
+	if code := status.Code(expressionError(errors.New("boom"))); code != codes.Internal {
+		t.Errorf("got %v, want Internal", code)
+	}
+}
@@ -2,64 +2,114 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
+	"math"
 	"net"
+	"os"
 
 	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"github.com/serhii12/grpc-go/certs"
+	"github.com/serhii12/grpc-go/config"
+	"github.com/serhii12/grpc-go/deadline"
+	"github.com/serhii12/grpc-go/logging"
+	"github.com/serhii12/grpc-go/metrics"
+	"github.com/serhii12/grpc-go/ratelimit"
+	"github.com/serhii12/grpc-go/recovery"
+	"github.com/serhii12/grpc-go/requestid"
+	"github.com/serhii12/grpc-go/shutdown"
+	"github.com/serhii12/grpc-go/streamerr"
+	"github.com/serhii12/grpc-go/tracing"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
-)
-
-const (
-	port = ":50051"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 // server is used to implement CalculatorServiceServer
 type server struct {
 	pb.UnimplementedCalculatorServiceServer
+
+	squareRoot squareRootConfig
+	primes     primesConfig
+	statistics statisticsConfig
+	aggregate  aggregateConfig
+	matrix     matrixConfig
+}
+
+func newServer(cfg *serverConfig) *server {
+	return &server{
+		squareRoot: cfg.SquareRoot,
+		primes:     cfg.Primes,
+		statistics: cfg.Statistics,
+		aggregate:  cfg.Aggregate,
+		matrix:     cfg.Matrix,
+	}
 }
 
 // Sum implements calculator.CalculatorServiceServer
 func (s *server) Sum(ctx context.Context, in *pb.SumRequest) (*pb.SumResponse, error) {
-	log.Printf("Received numbers: %v, %v", in.GetFirstNumber(), in.GetSecondNumber())
-
-	sum := in.GetFirstNumber() + in.GetSecondNumber()
+	sum := int64(in.GetFirstNumber()) + int64(in.GetSecondNumber())
+	if sum < math.MinInt32 || sum > math.MaxInt32 {
+		return nil, status.Errorf(
+			codes.OutOfRange,
+			fmt.Sprintf("Sum of %d and %d does not fit in int32, use BigSum", in.GetFirstNumber(), in.GetSecondNumber()),
+		)
+	}
 
 	return &pb.SumResponse{
-		SumResult: sum,
+		SumResult: int32(sum),
 	}, nil
 }
 
-func (s *server) PrimeNumberDecomposition(in *pb.PrimeNumberDecompositionRequest, stream pb.CalculatorService_PrimeNumberDecompositionServer) error {
-	fmt.Printf("GreetManyTimes function was invoked with %v\n", in)
+// SquareRoot implements calculator.CalculatorServiceServer
+func (s *server) SquareRoot(ctx context.Context, in *pb.SquareRootRequest) (*pb.SquareRootResponse, error) {
+	// an unset input is zero, which is what clients predating
+	// double_number send for 0
+	number, field := 0.0, "number"
+	switch input := in.GetInput().(type) {
+	case *pb.SquareRootRequest_Number:
+		number = float64(input.Number)
+	case *pb.SquareRootRequest_DoubleNumber:
+		number, field = input.DoubleNumber, "double_number"
+	}
 
-	n := in.GetNumber()
-	divisor := int64(2)
-	for n > 1 {
-		if n%divisor == 0 {
-			stream.Send(&pb.PrimeNumberDecompositionResponse{
-				PrimeFactor: divisor,
-			})
+	if number < 0 || math.IsNaN(number) || math.IsInf(number, 0) {
+		return nil, invalidArgument(field, fmt.Sprintf("must be a finite non-negative number, got %v", number))
+	}
 
-			n = n / divisor
-		} else {
-			divisor++
-			fmt.Printf("Divisor has increased to %v\n", divisor)
-		}
+	return &pb.SquareRootResponse{
+		NumberRoot: round(math.Sqrt(number), s.squareRoot.Precision),
+	}, nil
+}
+
+// round rounds x to places decimal places, or returns it as is for 0
+func round(x float64, places int) float64 {
+	if places == 0 {
+		return x
 	}
-	return nil
+
+	p := math.Pow(10, float64(places))
+	return math.Round(x*p) / p
 }
 
 func (s *server) ComputeAverage(stream pb.CalculatorService_ComputeAverageServer) error {
-	fmt.Printf("ComputeAverage function was invoked with %v\n", stream)
-
-	var sum int32
+	var sum int64
 	var count int
 	for {
 		req, err := stream.Recv()
 		if err == io.EOF {
 			// Finished reading
+			if count == 0 {
+				return status.Error(codes.InvalidArgument, "Cannot average an empty stream")
+			}
+
 			average := float64(sum) / float64(count)
 			return stream.SendAndClose(&pb.ComputeAverageResponse{
 				Average: average,
@@ -67,18 +117,24 @@ func (s *server) ComputeAverage(stream pb.CalculatorService_ComputeAverageServer
 		}
 
 		if err != nil {
-			log.Fatalf("Error reading file stream: %v", err)
+			return streamerr.Status(err, "read the client stream")
 		}
 
-		sum += req.GetNumber()
+		n := int64(req.GetNumber())
+		if (n > 0 && sum > math.MaxInt64-n) || (n < 0 && sum < math.MinInt64-n) {
+			return status.Errorf(
+				codes.OutOfRange,
+				"Sum of the numbers does not fit in int64, use BigComputeAverage",
+			)
+		}
+		sum += n
 		count++
 	}
 }
 
 func (s *server) FindMaximum(stream pb.CalculatorService_FindMaximumServer) error {
-	fmt.Printf("FindMaximum function was invoked with %v\n", stream)
-
 	var maximum int32
+	seen := false
 	for {
 		req, err := stream.Recv()
 		if err == io.EOF {
@@ -86,37 +142,129 @@ func (s *server) FindMaximum(stream pb.CalculatorService_FindMaximumServer) erro
 			return nil
 		}
 		if err != nil {
-			log.Fatalf("Error reading file stream: %v", err)
-			return err
+			return streamerr.Status(err, "read the client stream")
 		}
 
 		n := req.GetNumber()
 
-		if n > maximum {
+		// the first number is always reported, so all-negative input works
+		if !seen || n > maximum {
 			maximum = n
+			seen = true
 
 			if err := stream.Send(&pb.FindMaximumResponse{
 				Maximum: maximum,
 			}); err != nil {
-				log.Fatalf("Failed to send data to client: %v", err)
-				return err
+				return streamerr.Status(err, "send data to the client")
 			}
 		}
 	}
 }
 
+// invalidArgument rejects a request field, naming it in a BadRequest
+// detail so clients can tell which input to fix
+func invalidArgument(field, description string) error {
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("Invalid %s: %s", field, description))
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: description},
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
 func main() {
-	fmt.Println("Calculator Server")
+	cfg := defaultConfig()
+	if err := config.Load("calculator", cfg, os.Args[1:]); err != nil {
+		// -h already printed the usage
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		log.Fatal(err)
+	}
+	fmt.Printf("Effective config:\n%s", config.Format(cfg))
+
+	logger, err := logging.New(cfg.Log)
+	if err != nil {
+		log.Fatal(err)
+	}
+	slog.SetDefault(logger)
+
+	serverMetrics := metrics.New()
+	metricsServer, err := serverMetrics.Serve(cfg.Metrics)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	stopTracing, err := tracing.Setup(context.Background(), "calculator-server", cfg.Tracing)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	lis, err := net.Listen("tcp", port)
+	lis, err := net.Listen("tcp", cfg.Server.Address)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	opts, err := certs.ServerOptions(cfg.Server.TLS)
+	if err != nil {
+		log.Fatalf("failed to load TLS credentials: %v", err)
+	}
+	limiter := ratelimit.New(cfg.RateLimit)
+	deadlines := deadline.New(cfg.Deadlines)
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(
+			requestid.UnaryServerInterceptor(),
+			logging.UnaryServerInterceptor(logger, cfg.Log),
+			serverMetrics.UnaryServerInterceptor(),
+			limiter.UnaryServerInterceptor(),
+			deadlines.UnaryServerInterceptor(),
+			recovery.UnaryServerInterceptor(serverMetrics),
+		),
+		grpc.ChainStreamInterceptor(
+			requestid.StreamServerInterceptor(),
+			logging.StreamServerInterceptor(logger, cfg.Log),
+			serverMetrics.StreamServerInterceptor(),
+			limiter.StreamServerInterceptor(),
+			deadlines.StreamServerInterceptor(),
+			recovery.StreamServerInterceptor(serverMetrics),
+		),
+	)
+
+	if cfg.Tracing.Enabled {
+		opts = append(opts, tracing.ServerOption())
+	}
+
+	s := grpc.NewServer(opts...)
+
+	pb.RegisterCalculatorServiceServer(s, newServer(cfg))
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("calculator.CalculatorService", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, healthServer)
+
+	go func() {
+		slog.Info("Calculator Server started", "address", lis.Addr().String())
+
+		if err := s.Serve(lis); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+	}()
+
+	// Block until Control C or SIGTERM is received
+	sig := shutdown.Wait()
+	slog.Info("Stopping the server", "signal", sig.String())
+	shutdown.Graceful(s, healthServer, cfg.Server.ShutdownTimeout)
+	if metricsServer != nil {
+		metricsServer.Close()
+	}
+	slog.Info("Server stopped")
 
-	pb.RegisterCalculatorServiceServer(s, &server{})
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	if err := stopTracing(context.Background()); err != nil {
+		slog.Error("Failed to flush spans", "error", err)
 	}
 }
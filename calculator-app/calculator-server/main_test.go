@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"github.com/serhii12/grpc-go/internal/grpctest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// startServer serves the calculator with its default config over an
+// in-memory connection
+func startServer(t *testing.T) (pb.CalculatorServiceClient, *grpctest.Server) {
+	t.Helper()
+	s := grpctest.NewServer(t, func(s *grpc.Server) {
+		pb.RegisterCalculatorServiceServer(s, newServer(defaultConfig()))
+	})
+	return pb.NewCalculatorServiceClient(s.Conn), s
+}
+
+func TestStreamsSurviveClientDisconnect(t *testing.T) {
+	c, s := startServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		// start opens the stream and uses it partway before returning
+		start func(ctx context.Context) error
+		// done lists the codes the handler may end with; a short stream
+		// can be sent completely before the cancellation arrives
+		done []codes.Code
+	}{
+		{
+			name:   "PrimeNumberDecomposition",
+			method: "/calculator.CalculatorService/PrimeNumberDecomposition",
+			start: func(ctx context.Context) error {
+				stream, err := c.PrimeNumberDecomposition(ctx, &pb.PrimeNumberDecompositionRequest{Number: 1 << 62})
+				if err != nil {
+					return err
+				}
+				_, err = stream.Recv()
+				return err
+			},
+			done: []codes.Code{codes.Canceled, codes.OK},
+		},
+		{
+			name:   "PrimesInRange",
+			method: "/calculator.CalculatorService/PrimesInRange",
+			start: func(ctx context.Context) error {
+				stream, err := c.PrimesInRange(ctx, &pb.PrimesInRangeRequest{From: 1, To: 10000000})
+				if err != nil {
+					return err
+				}
+				_, err = stream.Recv()
+				return err
+			},
+			done: []codes.Code{codes.Canceled},
+		},
+		{
+			name:   "ComputeAverage",
+			method: "/calculator.CalculatorService/ComputeAverage",
+			start: func(ctx context.Context) error {
+				stream, err := c.ComputeAverage(ctx)
+				if err != nil {
+					return err
+				}
+				for _, n := range []int32{1, 2, 3} {
+					if err := stream.Send(&pb.ComputeAverageRequest{Number: n}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			done: []codes.Code{codes.Canceled},
+		},
+		{
+			name:   "FindMaximum",
+			method: "/calculator.CalculatorService/FindMaximum",
+			start: func(ctx context.Context) error {
+				stream, err := c.FindMaximum(ctx)
+				if err != nil {
+					return err
+				}
+				if err := stream.Send(&pb.FindMaximumRequest{Number: 4}); err != nil {
+					return err
+				}
+				_, err = stream.Recv()
+				return err
+			},
+			done: []codes.Code{codes.Canceled},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			if err := tt.start(ctx); err != nil {
+				t.Fatalf("starting the stream: %v", err)
+			}
+			cancel()
+
+			h := s.Wait(t, tt.method)
+			ok := false
+			for _, code := range tt.done {
+				ok = ok || h.Code == code
+			}
+			if !ok {
+				t.Errorf("handler returned %v, want one of %v", h.Code, tt.done)
+			}
+
+			resp, err := c.Sum(context.Background(), &pb.SumRequest{FirstNumber: 3, SecondNumber: 10})
+			if err != nil {
+				t.Fatalf("Sum after the disconnect: %v", err)
+			}
+			if resp.GetSumResult() != 13 {
+				t.Errorf("Sum = %d, want 13", resp.GetSumResult())
+			}
+		})
+	}
+}
+
+// violatedField returns the field of the BadRequest violation in err
+func violatedField(t *testing.T, err error) string {
+	t.Helper()
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("got %v, want InvalidArgument", err)
+	}
+
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok && len(br.GetFieldViolations()) == 1 {
+			return br.GetFieldViolations()[0].GetField()
+		}
+	}
+	t.Fatalf("%v has no BadRequest with one field violation", err)
+	return ""
+}
+
+func TestSquareRoot(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.SquareRoot.Precision = 3
+	s := newServer(cfg)
+
+	tests := []struct {
+		name      string
+		req       *pb.SquareRootRequest
+		want      float64
+		wantField string
+	}{
+		{name: "number", req: &pb.SquareRootRequest{Input: &pb.SquareRootRequest_Number{Number: 16}}, want: 4},
+		{name: "double number", req: &pb.SquareRootRequest{Input: &pb.SquareRootRequest_DoubleNumber{DoubleNumber: 2.25}}, want: 1.5},
+		{name: "rounded", req: &pb.SquareRootRequest{Input: &pb.SquareRootRequest_Number{Number: 2}}, want: 1.414},
+		{name: "unset is zero", req: &pb.SquareRootRequest{}, want: 0},
+		{name: "negative number", req: &pb.SquareRootRequest{Input: &pb.SquareRootRequest_Number{Number: -2}}, wantField: "number"},
+		{name: "negative double number", req: &pb.SquareRootRequest{Input: &pb.SquareRootRequest_DoubleNumber{DoubleNumber: -0.5}}, wantField: "double_number"},
+		{name: "NaN", req: &pb.SquareRootRequest{Input: &pb.SquareRootRequest_DoubleNumber{DoubleNumber: math.NaN()}}, wantField: "double_number"},
+		{name: "infinity", req: &pb.SquareRootRequest{Input: &pb.SquareRootRequest_DoubleNumber{DoubleNumber: math.Inf(1)}}, wantField: "double_number"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.SquareRoot(context.Background(), tt.req)
+			if tt.wantField != "" {
+				if field := violatedField(t, err); field != tt.wantField {
+					t.Errorf("violated field = %q, want %q", field, tt.wantField)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SquareRoot: %v", err)
+			}
+			if resp.GetNumberRoot() != tt.want {
+				t.Errorf("root = %v, want %v", resp.GetNumberRoot(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,506 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// matrix is a dense matrix stored row by row, like pb.Matrix
+type matrix struct {
+	rows, cols int
+	values     []float64
+}
+
+func newMatrix(rows, cols int) *matrix {
+	return &matrix{rows: rows, cols: cols, values: make([]float64, rows*cols)}
+}
+
+func (m *matrix) at(i, j int) float64 {
+	return m.values[i*m.cols+j]
+}
+
+func (m *matrix) dimensions() string {
+	return fmt.Sprintf("%dx%d", m.rows, m.cols)
+}
+
+func (s *server) readMatrix(field string, in *pb.Matrix) (*matrix, error) {
+	if in == nil {
+		return nil, invalidArgument(field, "is required")
+	}
+
+	rows, cols := int(in.GetRows()), int(in.GetCols())
+	if rows < 1 || cols < 1 {
+		return nil, invalidArgument(field, fmt.Sprintf("needs at least one row and column, got %dx%d", rows, cols))
+	}
+	if rows > s.matrix.MaxDimension || cols > s.matrix.MaxDimension {
+		return nil, invalidArgument(field, fmt.Sprintf("may have at most %d rows and columns, got %dx%d", s.matrix.MaxDimension, rows, cols))
+	}
+
+	values := in.GetValues()
+	if len(values) != rows*cols {
+		return nil, invalidArgument(field+".values", fmt.Sprintf("a %dx%d matrix needs %d values, got %d", rows, cols, rows*cols, len(values)))
+	}
+	for i, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, invalidArgument(field+".values", fmt.Sprintf("must be finite numbers, got %v in row %d, column %d", v, i/cols+1, i%cols+1))
+		}
+	}
+
+	return &matrix{rows: rows, cols: cols, values: values}, nil
+}
+
+func (s *server) readSquareMatrix(field string, in *pb.Matrix) (*matrix, error) {
+	m, err := s.readMatrix(field, in)
+	if err != nil {
+		return nil, err
+	}
+	if m.rows != m.cols {
+		return nil, invalidArgument(field, fmt.Sprintf("must be square, got %s", m.dimensions()))
+	}
+
+	return m, nil
+}
+
+func finite(values []float64) error {
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return status.Error(codes.OutOfRange, "The result does not fit in a double")
+		}
+	}
+
+	return nil
+}
+
+func matrixResult(m *matrix) (*pb.MatrixResponse, error) {
+	if err := finite(m.values); err != nil {
+		return nil, err
+	}
+
+	return &pb.MatrixResponse{
+		Result: &pb.Matrix{Rows: int32(m.rows), Cols: int32(m.cols), Values: m.values},
+	}, nil
+}
+
+// luFactors is PA = LU for a square matrix A: L below the diagonal with
+// an implicit unit diagonal and U on and above it share one slice, and
+// perm[i] is the row of A that ended up in row i
+type luFactors struct {
+	n        int
+	lu       []float64
+	perm     []int
+	sign     float64
+	singular bool
+}
+
+// factorLU runs Gaussian elimination with scaled partial pivoting, which
+// weighs each row by its largest value. Pivots that are tiny next to the
+// largest value of their row only show rounding error, so they make the
+// matrix singular, while badly scaled rows like those of diag(1e16, 1)
+// do not.
+func factorLU(ctx context.Context, m *matrix) (*luFactors, error) {
+	n := m.rows
+	f := &luFactors{
+		n:    n,
+		lu:   append([]float64(nil), m.values...),
+		perm: make([]int, n),
+		sign: 1,
+	}
+	scale := make([]float64, n)
+	for i := range f.perm {
+		f.perm[i] = i
+		for j := 0; j < n; j++ {
+			scale[i] = math.Max(scale[i], math.Abs(m.values[i*n+j]))
+		}
+	}
+
+	lu := f.lu
+	for k := 0; k < n; k++ {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+
+		// a row of zeros is never picked over another row, and makes the
+		// matrix singular once it is all that is left
+		p, best := k, -1.0
+		for i := k; i < n; i++ {
+			if scale[i] == 0 {
+				continue
+			}
+			if r := math.Abs(lu[i*n+k]) / scale[i]; r > best {
+				p, best = i, r
+			}
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				lu[k*n+j], lu[p*n+j] = lu[p*n+j], lu[k*n+j]
+			}
+			f.perm[k], f.perm[p] = f.perm[p], f.perm[k]
+			scale[k], scale[p] = scale[p], scale[k]
+			f.sign = -f.sign
+		}
+
+		pivot := lu[k*n+k]
+		if math.Abs(pivot) <= float64(n)*scale[k]*0x1p-52 {
+			f.singular = true
+		}
+		if pivot == 0 {
+			continue
+		}
+
+		for i := k + 1; i < n; i++ {
+			l := lu[i*n+k] / pivot
+			lu[i*n+k] = l
+			for j := k + 1; j < n; j++ {
+				lu[i*n+j] -= l * lu[k*n+j]
+			}
+		}
+	}
+
+	return f, nil
+}
+
+func (f *luFactors) determinant() float64 {
+	d := f.sign
+	for k := 0; k < f.n; k++ {
+		d *= f.lu[k*f.n+k]
+	}
+
+	return d
+}
+
+// solve returns x with Ax = b, the factors must not be singular
+func (f *luFactors) solve(b []float64) []float64 {
+	n, lu := f.n, f.lu
+	x := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x[i] = b[f.perm[i]]
+		for j := 0; j < i; j++ {
+			x[i] -= lu[i*n+j] * x[j]
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := i + 1; j < n; j++ {
+			x[i] -= lu[i*n+j] * x[j]
+		}
+		x[i] /= lu[i*n+i]
+	}
+
+	return x
+}
+
+// MatrixAdd implements calculator.CalculatorServiceServer
+func (s *server) MatrixAdd(ctx context.Context, in *pb.MatrixPairRequest) (*pb.MatrixResponse, error) {
+	a, err := s.readMatrix("first", in.GetFirst())
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.readMatrix("second", in.GetSecond())
+	if err != nil {
+		return nil, err
+	}
+	if a.rows != b.rows || a.cols != b.cols {
+		return nil, invalidArgument("second", fmt.Sprintf("must be %s like first, got %s", a.dimensions(), b.dimensions()))
+	}
+
+	sum := newMatrix(a.rows, a.cols)
+	for i := range sum.values {
+		sum.values[i] = a.values[i] + b.values[i]
+	}
+
+	return matrixResult(sum)
+}
+
+// MatrixMultiply implements calculator.CalculatorServiceServer
+func (s *server) MatrixMultiply(ctx context.Context, in *pb.MatrixPairRequest) (*pb.MatrixResponse, error) {
+	a, err := s.readMatrix("first", in.GetFirst())
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.readMatrix("second", in.GetSecond())
+	if err != nil {
+		return nil, err
+	}
+	if a.cols != b.rows {
+		return nil, invalidArgument("second.rows", fmt.Sprintf("must equal first.cols (%d), got %d", a.cols, b.rows))
+	}
+
+	product := newMatrix(a.rows, b.cols)
+	for i := 0; i < a.rows; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+
+		row := product.values[i*b.cols : (i+1)*b.cols]
+		for k := 0; k < a.cols; k++ {
+			aik := a.at(i, k)
+			for j := range row {
+				row[j] += aik * b.at(k, j)
+			}
+		}
+	}
+
+	return matrixResult(product)
+}
+
+// MatrixTranspose implements calculator.CalculatorServiceServer
+func (s *server) MatrixTranspose(ctx context.Context, in *pb.MatrixRequest) (*pb.MatrixResponse, error) {
+	m, err := s.readMatrix("matrix", in.GetMatrix())
+	if err != nil {
+		return nil, err
+	}
+
+	t := newMatrix(m.cols, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			t.values[j*t.cols+i] = m.at(i, j)
+		}
+	}
+
+	return matrixResult(t)
+}
+
+// Determinant implements calculator.CalculatorServiceServer
+func (s *server) Determinant(ctx context.Context, in *pb.MatrixRequest) (*pb.DeterminantResponse, error) {
+	m, err := s.readSquareMatrix("matrix", in.GetMatrix())
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := factorLU(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+
+	// a singular matrix would otherwise report its rounding error
+	d := 0.0
+	if !f.singular {
+		d = f.determinant()
+	}
+	if err := finite([]float64{d}); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeterminantResponse{Determinant: d}, nil
+}
+
+// MatrixInverse implements calculator.CalculatorServiceServer
+func (s *server) MatrixInverse(ctx context.Context, in *pb.MatrixRequest) (*pb.MatrixResponse, error) {
+	m, err := s.readSquareMatrix("matrix", in.GetMatrix())
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := factorLU(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	if f.singular {
+		return nil, invalidArgument("matrix", "is singular and has no inverse")
+	}
+
+	// column j of the inverse solves Ax = e_j
+	n := m.rows
+	inverse := newMatrix(n, n)
+	unit := make([]float64, n)
+	for j := 0; j < n; j++ {
+		if err := ctx.Err(); err != nil {
+			return nil, status.FromContextError(err).Err()
+		}
+
+		unit[j] = 1
+		for i, v := range f.solve(unit) {
+			inverse.values[i*n+j] = v
+		}
+		unit[j] = 0
+	}
+
+	return matrixResult(inverse)
+}
+
+// SolveLinearSystem implements calculator.CalculatorServiceServer
+func (s *server) SolveLinearSystem(ctx context.Context, in *pb.SolveLinearSystemRequest) (*pb.SolveLinearSystemResponse, error) {
+	a, err := s.readSquareMatrix("coefficients", in.GetCoefficients())
+	if err != nil {
+		return nil, err
+	}
+
+	b := in.GetConstants()
+	if len(b) != a.rows {
+		return nil, invalidArgument("constants", fmt.Sprintf("needs one value per row of coefficients (%d), got %d", a.rows, len(b)))
+	}
+	for _, v := range b {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, invalidArgument("constants", fmt.Sprintf("must be finite numbers, got %v", v))
+		}
+	}
+
+	f, err := factorLU(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	if f.singular {
+		return nil, invalidArgument("coefficients", "are singular, the system has no unique solution")
+	}
+
+	x := f.solve(b)
+	if err := finite(x); err != nil {
+		return nil, err
+	}
+
+	return &pb.SolveLinearSystemResponse{Solution: x}, nil
+}
+
+func (s *server) readVector(field string, in *pb.Vector) ([]float64, error) {
+	values := in.GetValues()
+	if len(values) == 0 {
+		return nil, invalidArgument(field, "needs at least one value")
+	}
+	if len(values) > s.matrix.MaxDimension {
+		return nil, invalidArgument(field, fmt.Sprintf("may have at most %d values, got %d", s.matrix.MaxDimension, len(values)))
+	}
+	for i, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, invalidArgument(field+".values", fmt.Sprintf("must be finite numbers, got %v at %d", v, i+1))
+		}
+	}
+
+	return values, nil
+}
+
+// readVectorPair reads two vectors of the same length, or of length
+// want when that is positive
+func (s *server) readVectorPair(in *pb.VectorPairRequest, want int) ([]float64, []float64, error) {
+	a, err := s.readVector("first", in.GetFirst())
+	if err != nil {
+		return nil, nil, err
+	}
+	if want > 0 && len(a) != want {
+		return nil, nil, invalidArgument("first", fmt.Sprintf("must have %d values, got %d", want, len(a)))
+	}
+
+	b, err := s.readVector("second", in.GetSecond())
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(b) != len(a) {
+		return nil, nil, invalidArgument("second", fmt.Sprintf("must have %d values like first, got %d", len(a), len(b)))
+	}
+
+	return a, b, nil
+}
+
+func vectorResult(values []float64) (*pb.VectorResponse, error) {
+	if err := finite(values); err != nil {
+		return nil, err
+	}
+
+	return &pb.VectorResponse{
+		Result: &pb.Vector{Values: values},
+	}, nil
+}
+
+func scalarResult(x float64) (*pb.ScalarResponse, error) {
+	if err := finite([]float64{x}); err != nil {
+		return nil, err
+	}
+
+	return &pb.ScalarResponse{Result: x}, nil
+}
+
+// VectorAdd implements calculator.CalculatorServiceServer
+func (s *server) VectorAdd(ctx context.Context, in *pb.VectorPairRequest) (*pb.VectorResponse, error) {
+	a, b, err := s.readVectorPair(in, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := make([]float64, len(a))
+	for i := range sum {
+		sum[i] = a[i] + b[i]
+	}
+
+	return vectorResult(sum)
+}
+
+// DotProduct implements calculator.CalculatorServiceServer
+func (s *server) DotProduct(ctx context.Context, in *pb.VectorPairRequest) (*pb.ScalarResponse, error) {
+	a, b, err := s.readVectorPair(in, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	dot := 0.0
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+
+	return scalarResult(dot)
+}
+
+// CrossProduct implements calculator.CalculatorServiceServer
+func (s *server) CrossProduct(ctx context.Context, in *pb.VectorPairRequest) (*pb.VectorResponse, error) {
+	a, b, err := s.readVectorPair(in, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	return vectorResult([]float64{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	})
+}
+
+// VectorNorm implements calculator.CalculatorServiceServer
+func (s *server) VectorNorm(ctx context.Context, in *pb.VectorRequest) (*pb.ScalarResponse, error) {
+	v, err := s.readVector("vector", in.GetVector())
+	if err != nil {
+		return nil, err
+	}
+
+	// scaling by the largest value keeps the squares from overflowing
+	// when the norm itself fits, as math.Hypot does for two values
+	largest := 0.0
+	for _, x := range v {
+		largest = math.Max(largest, math.Abs(x))
+	}
+	if largest == 0 {
+		return scalarResult(0)
+	}
+
+	squares := 0.0
+	for _, x := range v {
+		squares += (x / largest) * (x / largest)
+	}
+
+	return scalarResult(largest * math.Sqrt(squares))
+}
+
+// MatrixVectorMultiply implements calculator.CalculatorServiceServer
+func (s *server) MatrixVectorMultiply(ctx context.Context, in *pb.MatrixVectorMultiplyRequest) (*pb.VectorResponse, error) {
+	m, err := s.readMatrix("matrix", in.GetMatrix())
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.readVector("vector", in.GetVector())
+	if err != nil {
+		return nil, err
+	}
+	if len(v) != m.cols {
+		return nil, invalidArgument("vector", fmt.Sprintf("must have one value per column of matrix (%d), got %d", m.cols, len(v)))
+	}
+
+	product := make([]float64, m.rows)
+	for i := range product {
+		for j, x := range v {
+			product[i] += m.at(i, j) * x
+		}
+	}
+
+	return vectorResult(product)
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func square(values ...float64) *pb.Matrix {
+	n := int32(math.Sqrt(float64(len(values))))
+	return &pb.Matrix{Rows: n, Cols: n, Values: values}
+}
+
+func near(got, want []float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > 1e-12*math.Max(math.Abs(want[i]), 1e-300) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBadlyScaledMatrices(t *testing.T) {
+	s := newServer(defaultConfig())
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		matrix      *pb.Matrix
+		determinant float64
+		inverse     []float64
+		constants   []float64
+		solution    []float64
+	}{
+		{
+			name:        "diag(1e16, 1)",
+			matrix:      square(1e16, 0, 0, 1),
+			determinant: 1e16,
+			inverse:     []float64{1e-16, 0, 0, 1},
+			constants:   []float64{1e16, 2},
+			solution:    []float64{1, 2},
+		},
+		{
+			name:        "diag(1e16, 1, 1e-16)",
+			matrix:      square(1e16, 0, 0, 0, 1, 0, 0, 0, 1e-16),
+			determinant: 1,
+			inverse:     []float64{1e-16, 0, 0, 0, 1, 0, 0, 0, 1e16},
+			constants:   []float64{1e16, 2, 3e-16},
+			solution:    []float64{1, 2, 3},
+		},
+		{
+			name:        "one large row",
+			matrix:      square(1e20, 1e20, 1, 2),
+			determinant: 1e20,
+			inverse:     []float64{2e-20, -1, -1e-20, 1},
+			constants:   []float64{3e20, 5},
+			solution:    []float64{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			det, err := s.Determinant(ctx, &pb.MatrixRequest{Matrix: tt.matrix})
+			if err != nil {
+				t.Fatalf("Determinant: %v", err)
+			}
+			if !near([]float64{det.GetDeterminant()}, []float64{tt.determinant}) {
+				t.Errorf("determinant = %v, want %v", det.GetDeterminant(), tt.determinant)
+			}
+
+			inv, err := s.MatrixInverse(ctx, &pb.MatrixRequest{Matrix: tt.matrix})
+			if err != nil {
+				t.Fatalf("MatrixInverse: %v", err)
+			}
+			if !near(inv.GetResult().GetValues(), tt.inverse) {
+				t.Errorf("inverse = %v, want %v", inv.GetResult().GetValues(), tt.inverse)
+			}
+
+			x, err := s.SolveLinearSystem(ctx, &pb.SolveLinearSystemRequest{Coefficients: tt.matrix, Constants: tt.constants})
+			if err != nil {
+				t.Fatalf("SolveLinearSystem: %v", err)
+			}
+			if !near(x.GetSolution(), tt.solution) {
+				t.Errorf("solution = %v, want %v", x.GetSolution(), tt.solution)
+			}
+		})
+	}
+}
+
+func TestSingularMatrices(t *testing.T) {
+	s := newServer(defaultConfig())
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		matrix *pb.Matrix
+	}{
+		{name: "dependent rows", matrix: square(1, 2, 2, 4)},
+		{name: "dependent rows of different scale", matrix: square(1e16, 2e16, 1, 2)},
+		{name: "row of zeros", matrix: square(0, 0, 1, 2)},
+		{name: "all zeros", matrix: square(0, 0, 0, 0)},
+		{name: "rounding error only", matrix: square(1, 2, 3, 4, 5, 6, 7, 8, 9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			det, err := s.Determinant(ctx, &pb.MatrixRequest{Matrix: tt.matrix})
+			if err != nil {
+				t.Fatalf("Determinant: %v", err)
+			}
+			if det.GetDeterminant() != 0 {
+				t.Errorf("determinant = %v, want 0", det.GetDeterminant())
+			}
+
+			if _, err := s.MatrixInverse(ctx, &pb.MatrixRequest{Matrix: tt.matrix}); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("MatrixInverse: got %v, want InvalidArgument", err)
+			}
+
+			constants := make([]float64, tt.matrix.GetRows())
+			if _, err := s.SolveLinearSystem(ctx, &pb.SolveLinearSystemRequest{Coefficients: tt.matrix, Constants: constants}); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("SolveLinearSystem: got %v, want InvalidArgument", err)
+			}
+		})
+	}
+}
+
+func TestMatrixTranspose(t *testing.T) {
+	s := newServer(defaultConfig())
+
+	resp, err := s.MatrixTranspose(context.Background(), &pb.MatrixRequest{Matrix: &pb.Matrix{Rows: 2, Cols: 3, Values: []float64{1, 2, 3, 4, 5, 6}}})
+	if err != nil {
+		t.Fatalf("MatrixTranspose: %v", err)
+	}
+	got := resp.GetResult()
+	if got.GetRows() != 3 || got.GetCols() != 2 || !near(got.GetValues(), []float64{1, 4, 2, 5, 3, 6}) {
+		t.Errorf("transpose = %v", got)
+	}
+}
+
+func vector(values ...float64) *pb.Vector {
+	return &pb.Vector{Values: values}
+}
+
+func TestVectors(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Matrix.MaxDimension = 4
+	s := newServer(cfg)
+	ctx := context.Background()
+
+	pair := func(a, b *pb.Vector) *pb.VectorPairRequest {
+		return &pb.VectorPairRequest{First: a, Second: b}
+	}
+	vectorOp := func(op func(context.Context, *pb.VectorPairRequest) (*pb.VectorResponse, error)) func(*pb.VectorPairRequest) ([]float64, error) {
+		return func(in *pb.VectorPairRequest) ([]float64, error) {
+			resp, err := op(ctx, in)
+			return resp.GetResult().GetValues(), err
+		}
+	}
+	scalarOp := func(op func(context.Context, *pb.VectorPairRequest) (*pb.ScalarResponse, error)) func(*pb.VectorPairRequest) ([]float64, error) {
+		return func(in *pb.VectorPairRequest) ([]float64, error) {
+			resp, err := op(ctx, in)
+			return []float64{resp.GetResult()}, err
+		}
+	}
+	norm := func(in *pb.VectorPairRequest) ([]float64, error) {
+		resp, err := s.VectorNorm(ctx, &pb.VectorRequest{Vector: in.GetFirst()})
+		return []float64{resp.GetResult()}, err
+	}
+
+	tests := []struct {
+		name string
+		op   func(*pb.VectorPairRequest) ([]float64, error)
+		in   *pb.VectorPairRequest
+		want []float64
+		code codes.Code
+	}{
+		{name: "add", op: vectorOp(s.VectorAdd), in: pair(vector(1, 2), vector(3, -4)), want: []float64{4, -2}},
+		{name: "add different lengths", op: vectorOp(s.VectorAdd), in: pair(vector(1, 2), vector(3)), code: codes.InvalidArgument},
+		{name: "add overflowing", op: vectorOp(s.VectorAdd), in: pair(vector(math.MaxFloat64), vector(math.MaxFloat64)), code: codes.OutOfRange},
+		{name: "dot", op: scalarOp(s.DotProduct), in: pair(vector(1, 2, 3), vector(4, -5, 6)), want: []float64{12}},
+		{name: "dot of orthogonal vectors", op: scalarOp(s.DotProduct), in: pair(vector(1, 0), vector(0, 1)), want: []float64{0}},
+		{name: "dot of a missing vector", op: scalarOp(s.DotProduct), in: pair(vector(1), nil), code: codes.InvalidArgument},
+		{name: "cross", op: vectorOp(s.CrossProduct), in: pair(vector(1, 0, 0), vector(0, 1, 0)), want: []float64{0, 0, 1}},
+		{name: "cross anticommutes", op: vectorOp(s.CrossProduct), in: pair(vector(0, 1, 0), vector(1, 0, 0)), want: []float64{0, 0, -1}},
+		{name: "cross of parallel vectors", op: vectorOp(s.CrossProduct), in: pair(vector(1, 2, 3), vector(2, 4, 6)), want: []float64{0, 0, 0}},
+		{name: "cross of two values", op: vectorOp(s.CrossProduct), in: pair(vector(1, 2), vector(3, 4)), code: codes.InvalidArgument},
+		{name: "norm", op: norm, in: pair(vector(3, 4), nil), want: []float64{5}},
+		{name: "norm of zeros", op: norm, in: pair(vector(0, 0, 0), nil), want: []float64{0}},
+		// squaring these overflows, the norm does not
+		{name: "norm of huge values", op: norm, in: pair(vector(3e200, 4e200), nil), want: []float64{5e200}},
+		{name: "norm of tiny values", op: norm, in: pair(vector(3e-200, 4e-200), nil), want: []float64{5e-200}},
+		{name: "norm of an empty vector", op: norm, in: pair(vector(), nil), code: codes.InvalidArgument},
+		{name: "norm of NaN", op: norm, in: pair(vector(1, math.NaN()), nil), code: codes.InvalidArgument},
+		{name: "too long", op: norm, in: pair(vector(1, 2, 3, 4, 5), nil), code: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.op(tt.in)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got %v, want %v", err, tt.code)
+			}
+			if tt.code == codes.OK && !near(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatrixVectorMultiply(t *testing.T) {
+	s := newServer(defaultConfig())
+	m := &pb.Matrix{Rows: 2, Cols: 3, Values: []float64{1, 2, 3, 4, 5, 6}}
+
+	resp, err := s.MatrixVectorMultiply(context.Background(), &pb.MatrixVectorMultiplyRequest{Matrix: m, Vector: vector(1, 0, -1)})
+	if err != nil {
+		t.Fatalf("MatrixVectorMultiply: %v", err)
+	}
+	if got := resp.GetResult().GetValues(); !near(got, []float64{-2, -2}) {
+		t.Errorf("product = %v, want [-2 -2]", got)
+	}
+
+	_, err = s.MatrixVectorMultiply(context.Background(), &pb.MatrixVectorMultiplyRequest{Matrix: m, Vector: vector(1, 2)})
+	if field := violatedField(t, err); field != "vector" {
+		t.Errorf("violated field = %q, want vector", field)
+	}
+}
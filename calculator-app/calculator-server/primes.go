@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"github.com/serhii12/grpc-go/streamerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// trialLimit is the bound below which factors are found by trial division
+const trialLimit = 1000
+
+// smallPrimes are the primes below trialLimit
+var smallPrimes = sieve(trialLimit)
+
+// basePrimes sieve the segments of PrimesInRange. Above basePrimeLimit^2
+// they no longer rule out every composite, so what is left is confirmed
+// with isPrime.
+var basePrimes = sieve(basePrimeLimit)
+
+const (
+	basePrimeLimit = 1 << 20
+	segmentSize    = 1 << 16
+)
+
+// largestPrime is the largest prime that fits in an int64
+const largestPrime = math.MaxInt64 - 24
+
+// millerRabinBases make Miller-Rabin deterministic for every n < 3.3 * 10^24,
+// which covers all of uint64
+var millerRabinBases = []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// sieve returns the primes below limit
+func sieve(limit int) []uint64 {
+	composite := make([]bool, limit)
+	var primes []uint64
+	for i := 2; i < limit; i++ {
+		if composite[i] {
+			continue
+		}
+		primes = append(primes, uint64(i))
+		for j := i * i; j < limit; j += i {
+			composite[j] = true
+		}
+	}
+
+	return primes
+}
+
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+func powMod(b, e, m uint64) uint64 {
+	result := uint64(1) % m
+	for b %= m; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = mulMod(result, b, m)
+		}
+		b = mulMod(b, b, m)
+	}
+
+	return result
+}
+
+// isPrime runs a deterministic Miller-Rabin test
+func isPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range millerRabinBases {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+
+	d, s := n-1, 0
+	for d%2 == 0 {
+		d /= 2
+		s++
+	}
+
+	for _, a := range millerRabinBases {
+		x := powMod(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+
+		witness := true
+		for r := 1; r < s && witness; r++ {
+			x = mulMod(x, x, n)
+			witness = x != n-1
+		}
+		if witness {
+			return false
+		}
+	}
+
+	return true
+}
+
+func absDiff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+
+	return b - a
+}
+
+// pollardRho finds a non-trivial divisor of the odd composite n with
+// Brent's variant of Pollard's rho, trying new polynomials x^2 + c until
+// one succeeds or ctx is done
+func pollardRho(ctx context.Context, n uint64) (uint64, error) {
+	// gcds are taken over products of this many differences at a time
+	const batch = 128
+
+	for c := uint64(1); ; c++ {
+		// n < 2^63 + 1, so x^2 mod n + c cannot overflow
+		f := func(x uint64) uint64 { return (mulMod(x, x, n) + c) % n }
+
+		y, r, q, g := uint64(2), uint64(1), uint64(1), uint64(1)
+		var x, ys uint64
+		for g == 1 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+
+			x = y
+			for i := uint64(0); i < r; i++ {
+				y = f(y)
+			}
+			for k := uint64(0); k < r && g == 1; k += batch {
+				ys = y
+				for i := uint64(0); i < batch && i < r-k; i++ {
+					y = f(y)
+					q = mulMod(q, absDiff(x, y), n)
+				}
+				g = gcd(q, n)
+			}
+			r *= 2
+		}
+
+		// the batch overshot, step back through it one difference at a time
+		if g == n {
+			for g = 1; g == 1; {
+				ys = f(ys)
+				g = gcd(absDiff(x, ys), n)
+			}
+		}
+
+		if g != n {
+			return g, nil
+		}
+	}
+}
+
+// factorize returns the prime factors of n > 1 with multiplicity, unsorted
+func factorize(ctx context.Context, n uint64) ([]uint64, error) {
+	if isPrime(n) {
+		return []uint64{n}, nil
+	}
+	if n%2 == 0 {
+		rest, err := factorize(ctx, n/2)
+		return append(rest, 2), err
+	}
+
+	d, err := pollardRho(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+
+	left, err := factorize(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	right, err := factorize(ctx, n/d)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(left, right...), nil
+}
+
+// PrimeNumberDecomposition implements calculator.CalculatorServiceServer
+func (s *server) PrimeNumberDecomposition(in *pb.PrimeNumberDecompositionRequest, stream pb.CalculatorService_PrimeNumberDecompositionServer) error {
+	ctx := stream.Context()
+	n := in.GetNumber()
+	if n == 0 {
+		return invalidArgument("number", "0 has no prime factorization")
+	}
+
+	send := func(factor int64) error {
+		if err := stream.Send(&pb.PrimeNumberDecompositionResponse{
+			PrimeFactor: factor,
+		}); err != nil {
+			return streamerr.Status(err, "send data to the client")
+		}
+		return nil
+	}
+
+	if n < 0 {
+		if err := send(-1); err != nil {
+			return err
+		}
+	}
+
+	// small factors come out in order, so they are sent straight away
+	m := abs64(n)
+	for _, p := range smallPrimes {
+		for m%p == 0 {
+			if err := send(int64(p)); err != nil {
+				return err
+			}
+			m /= p
+		}
+	}
+	if m == 1 {
+		return nil
+	}
+
+	factors, err := factorize(ctx, m)
+	if err != nil {
+		return status.FromContextError(err).Err()
+	}
+	sort.Slice(factors, func(i, j int) bool { return factors[i] < factors[j] })
+
+	// every factor left is below 2^63, only |math.MinInt64| itself is not,
+	// and that is all twos
+	for _, f := range factors {
+		if err := send(int64(f)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsPrime implements calculator.CalculatorServiceServer
+func (s *server) IsPrime(ctx context.Context, in *pb.IsPrimeRequest) (*pb.IsPrimeResponse, error) {
+	n := in.GetNumber()
+
+	return &pb.IsPrimeResponse{
+		IsPrime: n > 1 && isPrime(uint64(n)),
+	}, nil
+}
+
+// NextPrime implements calculator.CalculatorServiceServer
+func (s *server) NextPrime(ctx context.Context, in *pb.NextPrimeRequest) (*pb.NextPrimeResponse, error) {
+	n := in.GetNumber()
+	if n >= largestPrime {
+		return nil, status.Errorf(
+			codes.OutOfRange,
+			fmt.Sprintf("There is no int64 prime greater than %d", n),
+		)
+	}
+	if n < 2 {
+		return &pb.NextPrimeResponse{Prime: 2}, nil
+	}
+
+	// gaps between primes below 2^63 are at most a few hundred
+	// odd numbers, so this ends quickly
+	candidate := uint64(n) + 1
+	if candidate%2 == 0 && candidate != 2 {
+		candidate++
+	}
+	for !isPrime(candidate) {
+		candidate += 2
+	}
+
+	return &pb.NextPrimeResponse{
+		Prime: int64(candidate),
+	}, nil
+}
+
+// PrimesInRange implements calculator.CalculatorServiceServer
+func (s *server) PrimesInRange(in *pb.PrimesInRangeRequest, stream pb.CalculatorService_PrimesInRangeServer) error {
+	from, to := in.GetFrom(), in.GetTo()
+	if to < from {
+		return invalidArgument("to", fmt.Sprintf("must not be less than from, got %d < %d", to, from))
+	}
+	// the difference of two int64 always fits in a uint64
+	if size := uint64(to-from) + 1; size == 0 || size > uint64(s.primes.MaxRange) {
+		return invalidArgument("to", fmt.Sprintf("the range may cover at most %d numbers", s.primes.MaxRange))
+	}
+	if to < 2 {
+		return nil
+	}
+	if from < 2 {
+		from = 2
+	}
+
+	return sieveRange(stream.Context(), uint64(from), uint64(to), func(primes []int64) error {
+		if err := stream.Send(&pb.PrimesInRangeResponse{
+			Primes: primes,
+		}); err != nil {
+			return streamerr.Status(err, "send data to the client")
+		}
+		return nil
+	})
+}
+
+// sieveRange passes the primes in [lo, hi], 2 <= lo <= hi < 2^63, to emit a
+// segment at a time. The next segment is only sieved once emit returns, so
+// a client reading slowly holds the server back through flow control
+// instead of making it buffer.
+func sieveRange(ctx context.Context, lo, hi uint64, emit func([]int64) error) error {
+	composite := make([]bool, segmentSize)
+	for segLo := lo; ; segLo += segmentSize {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		segHi := segLo + segmentSize - 1
+		if segHi > hi {
+			segHi = hi
+		}
+
+		marks := composite[:segHi-segLo+1]
+		for i := range marks {
+			marks[i] = false
+		}
+		for _, p := range basePrimes {
+			if p*p > segHi {
+				break
+			}
+
+			start := (segLo + p - 1) / p * p
+			if start < p*p {
+				start = p * p
+			}
+			for j := start; j <= segHi; j += p {
+				marks[j-segLo] = true
+			}
+		}
+
+		confirm := segHi/basePrimeLimit >= basePrimeLimit
+		var primes []int64
+		for i, c := range marks {
+			if n := segLo + uint64(i); !c && (!confirm || isPrime(n)) {
+				primes = append(primes, int64(n))
+			}
+		}
+
+		if len(primes) > 0 {
+			if err := emit(primes); err != nil {
+				return err
+			}
+		}
+
+		if segHi == hi {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"github.com/serhii12/grpc-go/internal/grpctest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPrimeNumberDecomposition(t *testing.T) {
+	c, _ := startServer(t)
+
+	tests := []struct {
+		name string
+		n    int64
+		want []int64
+		code codes.Code
+	}{
+		{name: "zero", n: 0, code: codes.InvalidArgument},
+		{name: "one", n: 1},
+		{name: "minus one", n: -1, want: []int64{-1}},
+		{name: "two", n: 2, want: []int64{2}},
+		{name: "minus two", n: -2, want: []int64{-1, 2}},
+		{name: "repeated factors", n: 120, want: []int64{2, 2, 2, 3, 5}},
+		{name: "Carmichael 561", n: 561, want: []int64{3, 11, 17}},
+		{name: "Carmichael 41041", n: 41041, want: []int64{7, 11, 13, 41}},
+		{name: "Carmichael 825265", n: 825265, want: []int64{5, 7, 17, 19, 73}},
+		// a strong pseudoprime to every prime base up to 23
+		{name: "strong pseudoprime", n: 3825123056546413051, want: []int64{149491, 747451, 34233211}},
+		{name: "largest prime", n: largestPrime, want: []int64{largestPrime}},
+		{name: "semiprime near 2^63", n: 3037000453 * 3037000493, want: []int64{3037000453, 3037000493}},
+		{name: "square near 2^63", n: 3037000493 * 3037000493, want: []int64{3037000493, 3037000493}},
+		{name: "small and large factor", n: 1000003 * 9223344366799, want: []int64{1000003, 9223344366799}},
+		{name: "MaxInt64", n: math.MaxInt64, want: []int64{7, 7, 73, 127, 337, 92737, 649657}},
+		{name: "MinInt64", n: math.MinInt64, want: append([]int64{-1}, repeat(2, 63)...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := c.PrimeNumberDecomposition(context.Background(), &pb.PrimeNumberDecompositionRequest{Number: tt.n})
+			if err != nil {
+				t.Fatalf("PrimeNumberDecomposition: %v", err)
+			}
+
+			var got []int64
+			for {
+				resp, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					if code := status.Code(err); code != tt.code {
+						t.Fatalf("got %v, want %v", err, tt.code)
+					}
+					return
+				}
+				got = append(got, resp.GetPrimeFactor())
+			}
+
+			if tt.code != codes.OK {
+				t.Fatalf("got factors %v, want %v", got, tt.code)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("factors of %d = %v, want %v", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func repeat(n int64, times int) []int64 {
+	s := make([]int64, times)
+	for i := range s {
+		s[i] = n
+	}
+	return s
+}
+
+func TestIsPrimeAgainstTrialDivision(t *testing.T) {
+	for n := uint64(0); n < 100000; n++ {
+		want := n >= 2
+		for d := uint64(2); d*d <= n && want; d++ {
+			want = n%d != 0
+		}
+		if got := isPrime(n); got != want {
+			t.Fatalf("isPrime(%d) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestIsPrimeLargeNumbers(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want bool
+	}{
+		{n: 3825123056546413051, want: false},
+		{n: 3037000453 * 3037000493, want: false},
+		{n: 3037000493 * 3037000493, want: false},
+		{n: largestPrime, want: true},
+		{n: 9223372036854775643, want: true},
+		{n: math.MaxInt64, want: false},
+		// beyond int64, which mulMod must still handle without overflowing
+		{n: 18446744073709551557, want: true},
+		{n: math.MaxUint64, want: false},
+		{n: 4294967291 * 4294967279, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := isPrime(tt.n); got != tt.want {
+			t.Errorf("isPrime(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestIsPrime(t *testing.T) {
+	s := newServer(defaultConfig())
+
+	tests := []struct {
+		n    int64
+		want bool
+	}{
+		{n: math.MinInt64},
+		{n: -7},
+		{n: 0},
+		{n: 1},
+		{n: 2, want: true},
+		{n: 3, want: true},
+		{n: 4},
+		{n: 561},
+		{n: 3825123056546413051},
+		{n: largestPrime, want: true},
+		{n: math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		resp, err := s.IsPrime(context.Background(), &pb.IsPrimeRequest{Number: tt.n})
+		if err != nil {
+			t.Fatalf("IsPrime(%d): %v", tt.n, err)
+		}
+		if resp.GetIsPrime() != tt.want {
+			t.Errorf("IsPrime(%d) = %v, want %v", tt.n, resp.GetIsPrime(), tt.want)
+		}
+	}
+}
+
+func TestNextPrime(t *testing.T) {
+	s := newServer(defaultConfig())
+
+	tests := []struct {
+		n    int64
+		want int64
+		code codes.Code
+	}{
+		{n: math.MinInt64, want: 2},
+		{n: 0, want: 2},
+		{n: 1, want: 2},
+		{n: 2, want: 3},
+		{n: 3, want: 5},
+		{n: 89, want: 97},
+		{n: 9223372036854775643, want: largestPrime},
+		{n: largestPrime - 1, want: largestPrime},
+		{n: largestPrime, code: codes.OutOfRange},
+		{n: math.MaxInt64, code: codes.OutOfRange},
+	}
+
+	for _, tt := range tests {
+		resp, err := s.NextPrime(context.Background(), &pb.NextPrimeRequest{Number: tt.n})
+		if code := status.Code(err); code != tt.code {
+			t.Fatalf("NextPrime(%d): got %v, want %v", tt.n, err, tt.code)
+		}
+		if tt.code == codes.OK && resp.GetPrime() != tt.want {
+			t.Errorf("NextPrime(%d) = %d, want %d", tt.n, resp.GetPrime(), tt.want)
+		}
+	}
+}
+
+func TestSieveRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		lo, hi uint64
+	}{
+		{name: "first segments", lo: 2, hi: 3*segmentSize + 17},
+		{name: "across one segment boundary", lo: segmentSize - 100, hi: segmentSize + 100},
+		{name: "ending on a segment boundary", lo: 2, hi: segmentSize + 1},
+		{name: "single number", lo: 7919, hi: 7919},
+		{name: "around the base primes' square", lo: basePrimeLimit*basePrimeLimit - segmentSize/2, hi: basePrimeLimit*basePrimeLimit + segmentSize/2},
+		{name: "up to MaxInt64", lo: math.MaxInt64 - 2*segmentSize, hi: math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var want []int64
+			for n := tt.lo; n <= tt.hi; n++ {
+				if isPrime(n) {
+					want = append(want, int64(n))
+				}
+			}
+
+			var got []int64
+			err := sieveRange(context.Background(), tt.lo, tt.hi, func(primes []int64) error {
+				if len(primes) == 0 {
+					t.Error("emitted no primes")
+				} else if primes[len(primes)-1]-primes[0] >= segmentSize {
+					t.Errorf("emitted primes from %d to %d, want one segment", primes[0], primes[len(primes)-1])
+				}
+				got = append(got, primes...)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("sieveRange: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("sieved %d primes, want %d", len(got), len(want))
+			}
+		})
+	}
+}
+
+func TestPrimesInRangeLimits(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Primes.MaxRange = 100
+	s := grpctest.NewServer(t, func(s *grpc.Server) {
+		pb.RegisterCalculatorServiceServer(s, newServer(cfg))
+	})
+	c := pb.NewCalculatorServiceClient(s.Conn)
+
+	tests := []struct {
+		name     string
+		from, to int64
+		want     int
+		code     codes.Code
+	}{
+		{name: "below two", from: -50, to: 1},
+		{name: "from below two", from: -10, to: 10, want: 4},
+		{name: "as large as allowed", from: 1, to: 100, want: 25},
+		{name: "one too large", from: 0, to: 100, code: codes.InvalidArgument},
+		{name: "reversed", from: 10, to: 9, code: codes.InvalidArgument},
+		// the size of this range overflows a uint64 to 0
+		{name: "all of int64", from: math.MinInt64, to: math.MaxInt64, code: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := c.PrimesInRange(context.Background(), &pb.PrimesInRangeRequest{From: tt.from, To: tt.to})
+			if err != nil {
+				t.Fatalf("PrimesInRange: %v", err)
+			}
+
+			got := 0
+			for {
+				resp, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				if code := status.Code(err); code != codes.OK {
+					if code != tt.code {
+						t.Fatalf("got %v, want %v", err, tt.code)
+					}
+					return
+				}
+				got += len(resp.GetPrimes())
+			}
+			if tt.code != codes.OK {
+				t.Fatalf("got %d primes, want %v", got, tt.code)
+			}
+			if got != tt.want {
+				t.Errorf("got %d primes, want %d", got, tt.want)
+			}
+		})
+	}
+}
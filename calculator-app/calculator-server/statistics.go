@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"github.com/serhii12/grpc-go/streamerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// digestCompression trades t-digest memory for accuracy
+	digestCompression = 100
+
+	// maxPercentiles bounds the percentiles one request may ask for
+	maxPercentiles = 100
+)
+
+// statistics accumulates a stream of numbers in constant memory
+type statistics struct {
+	count int64
+
+	// Welford's online mean and sum of squared deviations
+	mean float64
+	m2   float64
+
+	// Neumaier's compensated sum
+	sum          float64
+	compensation float64
+
+	min, max float64
+	digest   *tdigest
+}
+
+func newStatistics() *statistics {
+	return &statistics{
+		min:    math.Inf(1),
+		max:    math.Inf(-1),
+		digest: newTDigest(digestCompression),
+	}
+}
+
+func (s *statistics) add(x float64) {
+	s.count++
+	delta := x - s.mean
+	s.mean += delta / float64(s.count)
+	s.m2 += delta * (x - s.mean)
+
+	t := s.sum + x
+	if math.Abs(s.sum) >= math.Abs(x) {
+		s.compensation += (s.sum - t) + x
+	} else {
+		s.compensation += (x - t) + s.sum
+	}
+	s.sum = t
+
+	s.min = math.Min(s.min, x)
+	s.max = math.Max(s.max, x)
+	s.digest.add(x)
+}
+
+func (s *statistics) variance() float64 {
+	if s.count < 2 {
+		return 0
+	}
+
+	return s.m2 / float64(s.count-1)
+}
+
+func validPercentiles(percentiles []float64) error {
+	if len(percentiles) > maxPercentiles {
+		return invalidArgument("percentiles", fmt.Sprintf("at most %d may be requested", maxPercentiles))
+	}
+
+	for _, p := range percentiles {
+		if !(p >= 0 && p <= 100) {
+			return invalidArgument("percentiles", fmt.Sprintf("must be between 0 and 100, got %v", p))
+		}
+	}
+
+	return nil
+}
+
+// ComputeStatistics implements calculator.CalculatorServiceServer
+func (s *server) ComputeStatistics(stream pb.CalculatorService_ComputeStatisticsServer) error {
+	stats := newStatistics()
+	var percentiles []float64
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return streamerr.Status(err, "read the client stream")
+		}
+
+		if stats.count == 0 {
+			percentiles = req.GetPercentiles()
+			if err := validPercentiles(percentiles); err != nil {
+				return err
+			}
+		}
+
+		x := req.GetNumber()
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return invalidArgument("number", fmt.Sprintf("must be a finite number, got %v", x))
+		}
+		stats.add(x)
+	}
+
+	if stats.count == 0 {
+		return status.Error(codes.InvalidArgument, "Cannot compute statistics of an empty stream")
+	}
+	if len(percentiles) == 0 {
+		percentiles = s.statistics.Percentiles
+	}
+
+	resp := &pb.ComputeStatisticsResponse{
+		Count:             stats.count,
+		Sum:               stats.sum + stats.compensation,
+		Mean:              stats.mean,
+		Min:               stats.min,
+		Max:               stats.max,
+		Variance:          stats.variance(),
+		StandardDeviation: math.Sqrt(stats.variance()),
+		Median:            stats.digest.quantile(0.5),
+	}
+	for _, p := range percentiles {
+		resp.Percentiles = append(resp.Percentiles, &pb.Percentile{
+			Percentile: p,
+			Value:      stats.digest.quantile(p / 100),
+		})
+	}
+
+	for _, v := range []float64{resp.Sum, resp.Mean, resp.Variance} {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return status.Error(codes.OutOfRange, "The statistics of these numbers do not fit in a double")
+		}
+	}
+
+	return stream.SendAndClose(resp)
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatisticsAreNumericallyStable(t *testing.T) {
+	tests := []struct {
+		name     string
+		numbers  []float64
+		mean     float64
+		variance float64
+		sum      float64
+	}{
+		{name: "single number", numbers: []float64{3}, mean: 3, variance: 0, sum: 3},
+		{name: "small", numbers: []float64{4, 7, 13, 16}, mean: 10, variance: 30, sum: 40},
+		// the sum of squares minus the squared sum loses all of this
+		// variance to rounding, Welford's update keeps it
+		{name: "large offset", numbers: []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}, mean: 1e9 + 10, variance: 30, sum: 4e9 + 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStatistics()
+			for _, x := range tt.numbers {
+				s.add(x)
+			}
+
+			if got := s.sum + s.compensation; got != tt.sum {
+				t.Errorf("sum = %v, want %v", got, tt.sum)
+			}
+			if !closeTo(s.mean, tt.mean, 1e-9) {
+				t.Errorf("mean = %v, want %v", s.mean, tt.mean)
+			}
+			if !closeTo(s.variance(), tt.variance, 1e-9) {
+				t.Errorf("variance = %v, want %v", s.variance(), tt.variance)
+			}
+		})
+	}
+}
+
+func TestStatisticsSumIsCompensated(t *testing.T) {
+	s := newStatistics()
+	for _, x := range []float64{1e100, 1, -1e100, 0.1, 0.2} {
+		s.add(x)
+	}
+
+	// summed naively, 1e100 swallows the 1
+	if got := s.sum + s.compensation; got != 1.3 {
+		t.Errorf("sum = %v, want 1.3", got)
+	}
+}
+
+// closeTo reports whether got is within a relative tolerance of want
+func closeTo(got, want, tolerance float64) bool {
+	return math.Abs(got-want) <= tolerance*math.Max(1, math.Abs(want))
+}
+
+func TestTDigestIsExactForShortStreams(t *testing.T) {
+	d := newTDigest(digestCompression)
+	for _, x := range []float64{9, 1, 8, 2, 7, 3, 6, 4, 5} {
+		d.add(x)
+	}
+
+	tests := []struct{ q, want float64 }{
+		{0, 1}, {0.5, 5}, {1, 9},
+	}
+	for _, tt := range tests {
+		if got := d.quantile(tt.q); got != tt.want {
+			t.Errorf("quantile(%v) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+}
+
+func TestTDigestAccuracyAfterMerges(t *testing.T) {
+	const n = 100000
+	rng := rand.New(rand.NewSource(1))
+
+	orders := map[string][]float64{
+		"shuffled":   make([]float64, n),
+		"ascending":  make([]float64, n),
+		"descending": make([]float64, n),
+	}
+	for i := 0; i < n; i++ {
+		orders["ascending"][i] = float64(i)
+		orders["descending"][i] = float64(n - 1 - i)
+	}
+	for i, j := range rng.Perm(n) {
+		orders["shuffled"][i] = float64(j)
+	}
+
+	for name, numbers := range orders {
+		t.Run(name, func(t *testing.T) {
+			d := newTDigest(digestCompression)
+			for i, x := range numbers {
+				d.add(x)
+				// querying merges the buffer, halfway through as well as at
+				// the end, so later values are merged into merged centroids
+				if i == n/2 {
+					d.quantile(0.5)
+				}
+			}
+			if d.merge(); len(d.centroids) > digestCompression {
+				t.Errorf("kept %d centroids, want at most %d", len(d.centroids), digestCompression)
+			}
+
+			sorted := append([]float64(nil), numbers...)
+			sort.Float64s(sorted)
+			for _, q := range []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999} {
+				want := sorted[int(q*n)]
+				// the error allowed shrinks towards the tails like the
+				// centroids do
+				allowed := 0.01 * n * math.Max(math.Sqrt(q*(1-q)), 0.05)
+				if got := d.quantile(q); math.Abs(got-want) > allowed {
+					t.Errorf("quantile(%v) = %v, want %v ± %v", q, got, want, allowed)
+				}
+			}
+		})
+	}
+}
+
+func TestComputeStatistics(t *testing.T) {
+	c, _ := startServer(t)
+
+	tests := []struct {
+		name        string
+		requests    []*pb.ComputeStatisticsRequest
+		want        *pb.ComputeStatisticsResponse
+		percentiles map[float64]float64
+		code        codes.Code
+	}{
+		{
+			name: "numbers",
+			requests: []*pb.ComputeStatisticsRequest{
+				{Number: 4, Percentiles: []float64{0, 50, 100}}, {Number: 16}, {Number: 7}, {Number: 13},
+			},
+			want: &pb.ComputeStatisticsResponse{
+				Count: 4, Sum: 40, Mean: 10, Min: 4, Max: 16,
+				Variance: 30, StandardDeviation: math.Sqrt(30), Median: 10,
+			},
+			percentiles: map[float64]float64{0: 4, 50: 10, 100: 16},
+		},
+		{name: "empty stream", code: codes.InvalidArgument},
+		{name: "NaN", requests: []*pb.ComputeStatisticsRequest{{Number: 1}, {Number: math.NaN()}}, code: codes.InvalidArgument},
+		{name: "infinity", requests: []*pb.ComputeStatisticsRequest{{Number: math.Inf(-1)}}, code: codes.InvalidArgument},
+		{name: "percentile over 100", requests: []*pb.ComputeStatisticsRequest{{Number: 1, Percentiles: []float64{101}}}, code: codes.InvalidArgument},
+		{name: "overflowing sum", requests: []*pb.ComputeStatisticsRequest{{Number: math.MaxFloat64}, {Number: math.MaxFloat64}}, code: codes.OutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := c.ComputeStatistics(context.Background())
+			if err != nil {
+				t.Fatalf("ComputeStatistics: %v", err)
+			}
+			for _, req := range tt.requests {
+				if err := stream.Send(req); err != nil {
+					break
+				}
+			}
+
+			resp, err := stream.CloseAndRecv()
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got %v, want %v", err, tt.code)
+			}
+			if tt.code != codes.OK {
+				return
+			}
+
+			want := tt.want
+			if resp.GetCount() != want.GetCount() || resp.GetSum() != want.GetSum() || resp.GetMin() != want.GetMin() || resp.GetMax() != want.GetMax() {
+				t.Errorf("got count %d, sum %v, min %v, max %v", resp.GetCount(), resp.GetSum(), resp.GetMin(), resp.GetMax())
+			}
+			if !closeTo(resp.GetMean(), want.GetMean(), 1e-12) || !closeTo(resp.GetVariance(), want.GetVariance(), 1e-12) || !closeTo(resp.GetStandardDeviation(), want.GetStandardDeviation(), 1e-12) {
+				t.Errorf("got mean %v, variance %v, standard deviation %v", resp.GetMean(), resp.GetVariance(), resp.GetStandardDeviation())
+			}
+			if resp.GetMedian() != want.GetMedian() {
+				t.Errorf("median = %v, want %v", resp.GetMedian(), want.GetMedian())
+			}
+			if len(resp.GetPercentiles()) != len(tt.percentiles) {
+				t.Fatalf("got %d percentiles, want %d", len(resp.GetPercentiles()), len(tt.percentiles))
+			}
+			for _, p := range resp.GetPercentiles() {
+				if v, ok := tt.percentiles[p.GetPercentile()]; !ok || p.GetValue() != v {
+					t.Errorf("percentile %v = %v, want %v", p.GetPercentile(), p.GetValue(), v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// tdigest estimates quantiles of a stream in bounded memory, following
+// Dunning's merging t-digest: values are kept as centroids, small ones at
+// the tails and larger ones near the median, so extreme quantiles stay
+// accurate. Streams shorter than about compression/2 are kept exactly.
+type tdigest struct {
+	compression float64
+	centroids   []centroid // sorted by mean
+	buffer      []centroid // added since the last merge
+	total       float64
+	min, max    float64
+}
+
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+func newTDigest(compression float64) *tdigest {
+	return &tdigest{
+		compression: compression,
+		min:         math.Inf(1),
+		max:         math.Inf(-1),
+	}
+}
+
+func (t *tdigest) add(x float64) {
+	t.buffer = append(t.buffer, centroid{mean: x, weight: 1})
+	t.total++
+	t.min = math.Min(t.min, x)
+	t.max = math.Max(t.max, x)
+
+	if len(t.buffer) >= 5*int(t.compression) {
+		t.merge()
+	}
+}
+
+// scale maps a quantile to the k1 scale, on which no centroid may
+// span more than one unit
+func (t *tdigest) scale(q float64) float64 {
+	return t.compression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// merge folds the buffer into the centroids
+func (t *tdigest) merge() {
+	if len(t.buffer) == 0 {
+		return
+	}
+
+	all := append(t.centroids, t.buffer...)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	merged := all[:1]
+	before := 0.0 // weight of the centroids before the last merged one
+	for _, c := range all[1:] {
+		last := &merged[len(merged)-1]
+		if t.scale((before+last.weight+c.weight)/t.total)-t.scale(before/t.total) <= 1 {
+			last.weight += c.weight
+			last.mean += (c.mean - last.mean) * c.weight / last.weight
+			continue
+		}
+
+		before += last.weight
+		merged = append(merged, c)
+	}
+
+	t.centroids = merged
+	t.buffer = nil
+}
+
+// quantile estimates the q-th quantile, 0 <= q <= 1, interpolating between
+// centroid means and out to the exact min and max at the tails
+func (t *tdigest) quantile(q float64) float64 {
+	t.merge()
+
+	c := t.centroids
+	switch {
+	case len(c) == 0:
+		return math.NaN()
+	case q <= 0:
+		return t.min
+	case q >= 1:
+		return t.max
+	case len(c) == 1:
+		return c[0].mean
+	}
+
+	index := q * t.total
+	if half := c[0].weight / 2; index < half {
+		return t.min + (c[0].mean-t.min)*index/half
+	}
+
+	cumulative := c[0].weight / 2
+	for i := 0; i < len(c)-1; i++ {
+		step := (c[i].weight + c[i+1].weight) / 2
+		if cumulative+step > index {
+			return c[i].mean + (c[i+1].mean-c[i].mean)*(index-cumulative)/step
+		}
+		cumulative += step
+	}
+
+	last := c[len(c)-1]
+	return last.mean + (t.max-last.mean)*(index-cumulative)/(last.weight/2)
+}
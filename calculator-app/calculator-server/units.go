@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	dimLength = iota
+	dimMass
+	dimTime
+	dimTemperature
+	dimData
+	dimCount
+)
+
+var dimensionNames = [dimCount]string{"length", "mass", "time", "temperature", "data"}
+
+// dimension holds the exponent of each base dimension, m/s^2 is
+// length^1 time^-2
+type dimension [dimCount]int
+
+func baseDimension(d int) dimension {
+	var dim dimension
+	dim[d] = 1
+	return dim
+}
+
+func (d dimension) String() string {
+	var num, den []string
+	for i, exp := range d {
+		name := dimensionNames[i]
+		switch {
+		case exp == 1:
+			num = append(num, name)
+		case exp > 1:
+			num = append(num, fmt.Sprintf("%s^%d", name, exp))
+		case exp == -1:
+			den = append(den, name)
+		case exp < -1:
+			den = append(den, fmt.Sprintf("%s^%d", name, -exp))
+		}
+	}
+
+	switch {
+	case len(num) == 0 && len(den) == 0:
+		return "dimensionless"
+	case len(num) == 0:
+		num = []string{"1"}
+	}
+	if len(den) == 0 {
+		return strings.Join(num, "*")
+	}
+
+	return strings.Join(num, "*") + "/" + strings.Join(den, "*")
+}
+
+// unit converts to SI base units, and to bytes for data, as
+// value*factor + offset. Only temperature scales have an offset.
+type unit struct {
+	factor float64
+	offset float64
+	dim    dimension
+}
+
+type prefix struct {
+	symbol string
+	factor float64
+}
+
+var (
+	siPrefixes = []prefix{
+		{"n", 1e-9}, {"u", 1e-6}, {"µ", 1e-6}, {"μ", 1e-6}, {"m", 1e-3}, {"c", 1e-2}, {"d", 1e-1},
+		{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12},
+	}
+
+	dataPrefixes = []prefix{
+		{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15}, {"E", 1e18},
+		{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40}, {"Pi", 1 << 50}, {"Ei", 1 << 60},
+	}
+)
+
+// units maps every unit symbol, prefixed ones included, to its unit
+var units = buildUnits()
+
+func buildUnits() map[string]unit {
+	units := map[string]unit{}
+	add := func(symbol string, u unit, prefixes []prefix) {
+		for _, p := range append([]prefix{{"", 1}}, prefixes...) {
+			if _, ok := units[p.symbol+symbol]; ok {
+				panic("unit defined twice: " + p.symbol + symbol)
+			}
+			units[p.symbol+symbol] = unit{factor: p.factor * u.factor, offset: u.offset, dim: u.dim}
+		}
+	}
+
+	length := baseDimension(dimLength)
+	add("m", unit{factor: 1, dim: length}, siPrefixes)
+	add("in", unit{factor: 0.0254, dim: length}, nil)
+	add("ft", unit{factor: 0.3048, dim: length}, nil)
+	add("yd", unit{factor: 0.9144, dim: length}, nil)
+	add("mi", unit{factor: 1609.344, dim: length}, nil)
+	add("nmi", unit{factor: 1852, dim: length}, nil)
+
+	volume := dimension{dimLength: 3}
+	add("L", unit{factor: 1e-3, dim: volume}, siPrefixes)
+	add("l", unit{factor: 1e-3, dim: volume}, siPrefixes)
+
+	mass := baseDimension(dimMass)
+	add("g", unit{factor: 1e-3, dim: mass}, siPrefixes)
+	add("t", unit{factor: 1e3, dim: mass}, nil)
+	add("lb", unit{factor: 0.45359237, dim: mass}, nil)
+	add("oz", unit{factor: 0.45359237 / 16, dim: mass}, nil)
+	add("st", unit{factor: 0.45359237 * 14, dim: mass}, nil)
+
+	time := baseDimension(dimTime)
+	add("s", unit{factor: 1, dim: time}, siPrefixes)
+	add("min", unit{factor: 60, dim: time}, nil)
+	add("h", unit{factor: 3600, dim: time}, nil)
+	add("d", unit{factor: 86400, dim: time}, nil)
+	add("wk", unit{factor: 7 * 86400, dim: time}, nil)
+	// the Julian year of 365.25 days
+	add("yr", unit{factor: 365.25 * 86400, dim: time}, nil)
+
+	speed := dimension{dimLength: 1, dimTime: -1}
+	add("mph", unit{factor: 1609.344 / 3600, dim: speed}, nil)
+	add("kn", unit{factor: 1852.0 / 3600, dim: speed}, nil)
+
+	temperature := baseDimension(dimTemperature)
+	add("K", unit{factor: 1, dim: temperature}, nil)
+	for _, symbol := range []string{"°C", "degC"} {
+		add(symbol, unit{factor: 1, offset: 273.15, dim: temperature}, nil)
+	}
+	for _, symbol := range []string{"°F", "degF"} {
+		add(symbol, unit{factor: 5.0 / 9, offset: 459.67 * 5 / 9, dim: temperature}, nil)
+	}
+
+	data := baseDimension(dimData)
+	add("B", unit{factor: 1, dim: data}, dataPrefixes)
+	add("bit", unit{factor: 1.0 / 8, dim: data}, dataPrefixes)
+	add("b", unit{factor: 1.0 / 8, dim: data}, dataPrefixes)
+
+	return units
+}
+
+// unitNames are the spelled out names accepted besides the symbols,
+// matched regardless of case
+var unitNames = map[string]string{
+	"meter": "m", "meters": "m", "metre": "m", "metres": "m",
+	"kilometer": "km", "kilometers": "km", "kilometre": "km", "kilometres": "km",
+	"inch": "in", "inches": "in", "foot": "ft", "feet": "ft", "yard": "yd", "yards": "yd",
+	"mile": "mi", "miles": "mi",
+	"liter": "L", "liters": "L", "litre": "L", "litres": "L",
+	"gram": "g", "grams": "g", "kilogram": "kg", "kilograms": "kg", "tonne": "t", "tonnes": "t",
+	"pound": "lb", "pounds": "lb", "ounce": "oz", "ounces": "oz",
+	"second": "s", "seconds": "s", "minute": "min", "minutes": "min", "hour": "h", "hours": "h",
+	"day": "d", "days": "d", "week": "wk", "weeks": "wk", "year": "yr", "years": "yr",
+	"kelvin": "K", "celsius": "°C", "fahrenheit": "°F",
+	"byte": "B", "bytes": "B", "bits": "bit",
+}
+
+func lookupUnit(name string) (unit, bool) {
+	if u, ok := units[name]; ok {
+		return u, true
+	}
+	u, ok := units[unitNames[strings.ToLower(name)]]
+
+	return u, ok
+}
+
+// maxUnitExponent keeps factors like km^n well inside a double
+const maxUnitExponent = 9
+
+// parseUnit reads a unit like km, m/s^2 or kg*m/s². Each / divides by
+// the unit right after it only, so km/h/s is km/(h*s); a space multiplies
+// like *. Temperature scales with an offset, like °C, must stand alone.
+func parseUnit(expr string) (unit, error) {
+	rest := strings.TrimSpace(expr)
+	if rest == "" {
+		return unit{}, fmt.Errorf("must not be empty")
+	}
+
+	result := unit{factor: 1}
+	sign, terms := 1, 0
+	offsetUnit := ""
+	for {
+		end := strings.IndexAny(rest, "*·/ ")
+		if end < 0 {
+			end = len(rest)
+		}
+		term := rest[:end]
+		if term == "" {
+			return unit{}, fmt.Errorf("missing a unit in %q", expr)
+		}
+
+		name, exp, err := splitExponent(term)
+		if err != nil {
+			return unit{}, err
+		}
+		u, ok := lookupUnit(name)
+		if !ok {
+			return unit{}, fmt.Errorf("unknown unit %q", name)
+		}
+
+		exp *= sign
+		result.factor *= math.Pow(u.factor, float64(exp))
+		for i := range result.dim {
+			result.dim[i] += exp * u.dim[i]
+		}
+		if u.offset != 0 {
+			offsetUnit = name
+			result.offset = u.offset
+			if exp != 1 {
+				terms++
+			}
+		}
+		terms++
+
+		rest = strings.TrimLeft(rest[end:], " ")
+		if rest == "" {
+			break
+		}
+		sign = 1
+		r, size := utf8.DecodeRuneInString(rest)
+		switch r {
+		case '/':
+			sign = -1
+			rest = rest[size:]
+		case '*', '·':
+			rest = rest[size:]
+		}
+		rest = strings.TrimLeft(rest, " ")
+	}
+
+	if offsetUnit != "" && terms > 1 {
+		return unit{}, fmt.Errorf("%s can only be converted on its own, use K in compound units", offsetUnit)
+	}
+
+	return result, nil
+}
+
+// splitExponent splits m^2, m^-1 or m³ into the unit and its exponent
+func splitExponent(term string) (string, int, error) {
+	name, exp := term, 1
+	if i := strings.IndexByte(term, '^'); i >= 0 {
+		n, err := strconv.Atoi(term[i+1:])
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid exponent in %q", term)
+		}
+		name, exp = term[:i], n
+	} else if strings.HasSuffix(term, "²") {
+		name, exp = strings.TrimSuffix(term, "²"), 2
+	} else if strings.HasSuffix(term, "³") {
+		name, exp = strings.TrimSuffix(term, "³"), 3
+	}
+
+	if exp == 0 || exp < -maxUnitExponent || exp > maxUnitExponent {
+		return "", 0, fmt.Errorf("exponent in %q must be between -%d and %d and not 0", term, maxUnitExponent, maxUnitExponent)
+	}
+
+	return name, exp, nil
+}
+
+// Convert implements calculator.CalculatorServiceServer
+func (s *server) Convert(ctx context.Context, in *pb.ConvertRequest) (*pb.ConvertResponse, error) {
+	v := in.GetValue()
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil, invalidArgument("value", fmt.Sprintf("must be a finite number, got %v", v))
+	}
+
+	from, err := parseUnit(in.GetFrom())
+	if err != nil {
+		return nil, invalidArgument("from", err.Error())
+	}
+	to, err := parseUnit(in.GetTo())
+	if err != nil {
+		return nil, invalidArgument("to", err.Error())
+	}
+	if from.dim != to.dim {
+		return nil, invalidArgument("to", fmt.Sprintf(
+			"cannot convert %s (%s) to %s (%s)", in.GetFrom(), from.dim, in.GetTo(), to.dim,
+		))
+	}
+
+	si := v*from.factor + from.offset
+	result := (si - to.offset) / to.factor
+	if math.IsInf(result, 0) || math.IsNaN(result) {
+		return nil, status.Error(codes.OutOfRange, "The converted value does not fit in a double")
+	}
+
+	// the offsets of temperature scales cancel out, so the rounding error
+	// follows the value before that, not the result
+	result = roundSignificant(result, math.Max(math.Abs(result), math.Abs(si/to.factor)))
+
+	return &pb.ConvertResponse{Value: result}, nil
+}
+
+// roundSignificant rounds x to the 15 significant decimal digits a double
+// holds, counted from magnitude, which drops the noise of the factors so
+// 212 °F is 100 °C and not 100.00000000000006
+func roundSignificant(x, magnitude float64) float64 {
+	if magnitude == 0 {
+		return x
+	}
+
+	var text string
+	if places := 14 - int(math.Floor(math.Log10(magnitude))); places > 0 {
+		text = strconv.FormatFloat(x, 'f', places, 64)
+	} else {
+		text = strconv.FormatFloat(x, 'g', 15, 64)
+	}
+	rounded, _ := strconv.ParseFloat(text, 64)
+
+	return rounded
+}
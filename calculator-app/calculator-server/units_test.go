@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	pb "github.com/serhii12/grpc-go/calculator-app/calculatorpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseUnit(t *testing.T) {
+	tests := []struct {
+		expr    string
+		factor  float64
+		offset  float64
+		dim     dimension
+		wantErr string
+	}{
+		{expr: "km", factor: 1000, dim: dimension{dimLength: 1}},
+		{expr: " kilometres ", factor: 1000, dim: dimension{dimLength: 1}},
+		{expr: "m/s^2", factor: 1, dim: dimension{dimLength: 1, dimTime: -2}},
+		{expr: "kg*m/s²", factor: 1, dim: dimension{dimLength: 1, dimMass: 1, dimTime: -2}},
+		{expr: "kg·m", factor: 1, dim: dimension{dimLength: 1, dimMass: 1}},
+		{expr: "kg m", factor: 1, dim: dimension{dimLength: 1, dimMass: 1}},
+		// each / divides by the next unit only
+		{expr: "km/h/s", factor: 1000.0 / 3600, dim: dimension{dimLength: 1, dimTime: -2}},
+		{expr: "km / h", factor: 1000.0 / 3600, dim: dimension{dimLength: 1, dimTime: -1}},
+		{expr: "m^-1", factor: 1, dim: dimension{dimLength: -1}},
+		{expr: "cm³", factor: 1e-6, dim: dimension{dimLength: 3}},
+		{expr: "L", factor: 1e-3, dim: dimension{dimLength: 3}},
+		{expr: "mph", factor: 0.44704, dim: dimension{dimLength: 1, dimTime: -1}},
+		{expr: "MiB/s", factor: 1 << 20, dim: dimension{dimData: 1, dimTime: -1}},
+		{expr: "m/m", factor: 1},
+		{expr: "°C", factor: 1, offset: 273.15, dim: dimension{dimTemperature: 1}},
+		{expr: "Fahrenheit", factor: 5.0 / 9, offset: 459.67 * 5 / 9, dim: dimension{dimTemperature: 1}},
+		{expr: "K/m", factor: 1, dim: dimension{dimTemperature: 1, dimLength: -1}},
+		{expr: "", wantErr: "must not be empty"},
+		{expr: "m/", wantErr: "missing a unit"},
+		{expr: "m**s", wantErr: "missing a unit"},
+		{expr: "furlong", wantErr: `unknown unit "furlong"`},
+		{expr: "m^x", wantErr: "invalid exponent"},
+		{expr: "m^0", wantErr: "must be between"},
+		{expr: "m^10", wantErr: "must be between"},
+		{expr: "°C/s", wantErr: "use K in compound units"},
+		{expr: "degC^2", wantErr: "use K in compound units"},
+		{expr: "m*°F", wantErr: "use K in compound units"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			u, err := parseUnit(tt.expr)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got %+v, %v, want an error containing %q", u, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseUnit: %v", err)
+			}
+			if !closeTo(u.factor, tt.factor, 1e-12) || !closeTo(u.offset, tt.offset, 1e-12) || u.dim != tt.dim {
+				t.Errorf("got factor %v, offset %v, %v, want %v, %v, %v", u.factor, u.offset, u.dim, tt.factor, tt.offset, tt.dim)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	s := newServer(defaultConfig())
+
+	tests := []struct {
+		value    float64
+		from, to string
+		want     float64
+	}{
+		{value: 1, from: "km", to: "m", want: 1000},
+		{value: 1, from: "mi", to: "km", want: 1.609344},
+		{value: 100, from: "km/h", to: "m/s", want: 27.7777777777778},
+		{value: 1, from: "kn", to: "km/h", want: 1.852},
+		{value: 9.81, from: "m/s²", to: "ft/s^2", want: 32.1850393700787},
+		{value: 1, from: "L", to: "cm^3", want: 1000},
+		{value: 1, from: "lb", to: "g", want: 453.59237},
+		{value: 1, from: "yr", to: "d", want: 365.25},
+		{value: 1, from: "GiB", to: "MiB", want: 1024},
+		{value: 1, from: "GB", to: "MB", want: 1000},
+		{value: 8, from: "bit", to: "B", want: 1},
+		{value: 1, from: "Gb/s", to: "MB/s", want: 125},
+		// temperature scales are affine, not just scaled
+		{value: 212, from: "°F", to: "°C", want: 100},
+		{value: 32, from: "degF", to: "K", want: 273.15},
+		{value: -40, from: "celsius", to: "fahrenheit", want: -40},
+		{value: 0, from: "K", to: "°C", want: -273.15},
+		{value: 0, from: "°C", to: "°F", want: 32},
+		{value: 37, from: "°C", to: "°C", want: 37},
+		// temperature differences per length are scaled only
+		{value: 1, from: "K/m", to: "K/km", want: 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+" to "+tt.to, func(t *testing.T) {
+			resp, err := s.Convert(context.Background(), &pb.ConvertRequest{Value: tt.value, From: tt.from, To: tt.to})
+			if err != nil {
+				t.Fatalf("Convert: %v", err)
+			}
+			if !closeTo(resp.GetValue(), tt.want, 1e-12) {
+				t.Errorf("%v %s = %v %s, want %v", tt.value, tt.from, resp.GetValue(), tt.to, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	s := newServer(defaultConfig())
+
+	tests := []struct {
+		name      string
+		req       *pb.ConvertRequest
+		wantField string
+		wantMsg   string
+	}{
+		{name: "length to time", req: &pb.ConvertRequest{Value: 1, From: "m", To: "s"}, wantField: "to", wantMsg: "cannot convert m (length) to s (time)"},
+		{name: "speed to length", req: &pb.ConvertRequest{Value: 1, From: "km/h", To: "km"}, wantField: "to", wantMsg: "(length/time) to km (length)"},
+		{name: "mass to temperature", req: &pb.ConvertRequest{Value: 1, From: "kg", To: "°C"}, wantField: "to", wantMsg: "(mass) to °C (temperature)"},
+		{name: "acceleration to speed", req: &pb.ConvertRequest{Value: 1, From: "m/s^2", To: "m/s"}, wantField: "to", wantMsg: "(length/time^2)"},
+		{name: "volume to area", req: &pb.ConvertRequest{Value: 1, From: "L", To: "m^2"}, wantField: "to", wantMsg: "(length^3) to m^2 (length^2)"},
+		{name: "dimensionless", req: &pb.ConvertRequest{Value: 1, From: "m/km", To: "B"}, wantField: "to", wantMsg: "(dimensionless)"},
+		{name: "unknown from", req: &pb.ConvertRequest{Value: 1, From: "parsec", To: "m"}, wantField: "from"},
+		{name: "unknown to", req: &pb.ConvertRequest{Value: 1, From: "m", To: "cubit"}, wantField: "to"},
+		{name: "NaN", req: &pb.ConvertRequest{Value: math.NaN(), From: "m", To: "km"}, wantField: "value"},
+		{name: "infinity", req: &pb.ConvertRequest{Value: math.Inf(1), From: "m", To: "km"}, wantField: "value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Convert(context.Background(), tt.req)
+			if field := violatedField(t, err); field != tt.wantField {
+				t.Errorf("violated field = %q, want %q", field, tt.wantField)
+			}
+			if msg := status.Convert(err).Message(); !strings.Contains(msg, tt.wantMsg) {
+				t.Errorf("message %q does not contain %q", msg, tt.wantMsg)
+			}
+		})
+	}
+
+	_, err := s.Convert(context.Background(), &pb.ConvertRequest{Value: 1e308, From: "m", To: "nm"})
+	if status.Code(err) != codes.OutOfRange {
+		t.Errorf("converting past the largest double: got %v, want OutOfRange", err)
+	}
+}
@@ -24,6 +24,40 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type Aggregate int32
+
+const (
+	Aggregate_AGGREGATE_UNSPECIFIED Aggregate = 0
+	Aggregate_AGGREGATE_MAX         Aggregate = 1
+	Aggregate_AGGREGATE_MIN         Aggregate = 2
+	Aggregate_AGGREGATE_MEAN        Aggregate = 3
+	Aggregate_AGGREGATE_SUM         Aggregate = 4
+)
+
+var Aggregate_name = map[int32]string{
+	0: "AGGREGATE_UNSPECIFIED",
+	1: "AGGREGATE_MAX",
+	2: "AGGREGATE_MIN",
+	3: "AGGREGATE_MEAN",
+	4: "AGGREGATE_SUM",
+}
+
+var Aggregate_value = map[string]int32{
+	"AGGREGATE_UNSPECIFIED": 0,
+	"AGGREGATE_MAX":         1,
+	"AGGREGATE_MIN":         2,
+	"AGGREGATE_MEAN":        3,
+	"AGGREGATE_SUM":         4,
+}
+
+func (x Aggregate) String() string {
+	return proto.EnumName(Aggregate_name, int32(x))
+}
+
+func (Aggregate) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{0}
+}
+
 type SumRequest struct {
 	FirstNumber          int32    `protobuf:"varint,1,opt,name=first_number,json=firstNumber,proto3" json:"first_number,omitempty"`
 	SecondNumber         int32    `protobuf:"varint,2,opt,name=second_number,json=secondNumber,proto3" json:"second_number,omitempty"`
@@ -345,10 +379,13 @@ func (m *FindMaximumResponse) GetMaximum() int32 {
 }
 
 type SquareRootRequest struct {
-	Number               int32    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	// Types that are valid to be assigned to Input:
+	//	*SquareRootRequest_Number
+	//	*SquareRootRequest_DoubleNumber
+	Input                isSquareRootRequest_Input `protobuf_oneof:"input"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
 func (m *SquareRootRequest) Reset()         { *m = SquareRootRequest{} }
@@ -376,13 +413,51 @@ func (m *SquareRootRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SquareRootRequest proto.InternalMessageInfo
 
-func (m *SquareRootRequest) GetNumber() int32 {
+type isSquareRootRequest_Input interface {
+	isSquareRootRequest_Input()
+}
+
+type SquareRootRequest_Number struct {
+	Number int32 `protobuf:"varint,1,opt,name=number,proto3,oneof"`
+}
+
+type SquareRootRequest_DoubleNumber struct {
+	DoubleNumber float64 `protobuf:"fixed64,2,opt,name=double_number,json=doubleNumber,proto3,oneof"`
+}
+
+func (*SquareRootRequest_Number) isSquareRootRequest_Input() {}
+
+func (*SquareRootRequest_DoubleNumber) isSquareRootRequest_Input() {}
+
+func (m *SquareRootRequest) GetInput() isSquareRootRequest_Input {
 	if m != nil {
-		return m.Number
+		return m.Input
+	}
+	return nil
+}
+
+func (m *SquareRootRequest) GetNumber() int32 {
+	if x, ok := m.GetInput().(*SquareRootRequest_Number); ok {
+		return x.Number
+	}
+	return 0
+}
+
+func (m *SquareRootRequest) GetDoubleNumber() float64 {
+	if x, ok := m.GetInput().(*SquareRootRequest_DoubleNumber); ok {
+		return x.DoubleNumber
 	}
 	return 0
 }
 
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*SquareRootRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*SquareRootRequest_Number)(nil),
+		(*SquareRootRequest_DoubleNumber)(nil),
+	}
+}
+
 type SquareRootResponse struct {
 	NumberRoot           float64  `protobuf:"fixed64,1,opt,name=numberRoot,proto3" json:"numberRoot,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -422,342 +497,3394 @@ func (m *SquareRootResponse) GetNumberRoot() float64 {
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*SumRequest)(nil), "calculator.SumRequest")
-	proto.RegisterType((*SumResponse)(nil), "calculator.SumResponse")
-	proto.RegisterType((*PrimeNumberDecompositionRequest)(nil), "calculator.PrimeNumberDecompositionRequest")
-	proto.RegisterType((*PrimeNumberDecompositionResponse)(nil), "calculator.PrimeNumberDecompositionResponse")
-	proto.RegisterType((*ComputeAverageRequest)(nil), "calculator.ComputeAverageRequest")
-	proto.RegisterType((*ComputeAverageResponse)(nil), "calculator.ComputeAverageResponse")
-	proto.RegisterType((*FindMaximumRequest)(nil), "calculator.FindMaximumRequest")
-	proto.RegisterType((*FindMaximumResponse)(nil), "calculator.FindMaximumResponse")
-	proto.RegisterType((*SquareRootRequest)(nil), "calculator.SquareRootRequest")
-	proto.RegisterType((*SquareRootResponse)(nil), "calculator.SquareRootResponse")
+type BigSumRequest struct {
+	FirstNumber          string   `protobuf:"bytes,1,opt,name=first_number,json=firstNumber,proto3" json:"first_number,omitempty"`
+	SecondNumber         string   `protobuf:"bytes,2,opt,name=second_number,json=secondNumber,proto3" json:"second_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterFile("calculator-app/calculatorpb/calculator.proto", fileDescriptor_c3514e02e3bedff2)
+func (m *BigSumRequest) Reset()         { *m = BigSumRequest{} }
+func (m *BigSumRequest) String() string { return proto.CompactTextString(m) }
+func (*BigSumRequest) ProtoMessage()    {}
+func (*BigSumRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{10}
 }
 
-var fileDescriptor_c3514e02e3bedff2 = []byte{
-	// 431 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4d, 0x6f, 0xd3, 0x30,
-	0x18, 0x26, 0x54, 0x1b, 0xe2, 0x4d, 0x98, 0x34, 0x23, 0x4a, 0x15, 0x69, 0xdd, 0x6a, 0x2e, 0x93,
-	0x16, 0xd6, 0x69, 0x20, 0x24, 0x8e, 0x30, 0xd8, 0x05, 0x81, 0x50, 0xb2, 0x13, 0x1c, 0x2a, 0x37,
-	0xf3, 0x50, 0xa4, 0x38, 0xf6, 0xfc, 0x31, 0xf8, 0x81, 0xfc, 0x30, 0x34, 0xdb, 0x69, 0xdc, 0x8f,
-	0xd0, 0x1d, 0xdf, 0xc7, 0xcf, 0x87, 0xe5, 0xe7, 0x95, 0x21, 0x2b, 0x49, 0x5d, 0x9a, 0x9a, 0x68,
-	0x2e, 0x5f, 0x13, 0x21, 0xa6, 0xdd, 0x28, 0xe6, 0xc1, 0x70, 0x2a, 0x24, 0xd7, 0x1c, 0x41, 0x87,
-	0xe0, 0x2b, 0x80, 0xc2, 0xb0, 0x9c, 0xde, 0x1a, 0xaa, 0x34, 0x9a, 0x40, 0x72, 0x53, 0x49, 0xa5,
-	0x67, 0x8d, 0x61, 0x73, 0x2a, 0x47, 0xd1, 0x51, 0x74, 0xbc, 0x93, 0xc7, 0x16, 0xfb, 0x66, 0x21,
-	0xf4, 0x0a, 0x9e, 0x29, 0x5a, 0xf2, 0xe6, 0xba, 0xe5, 0x3c, 0xb6, 0x9c, 0xc4, 0x81, 0x8e, 0x84,
-	0x33, 0x88, 0xad, 0xab, 0x12, 0xbc, 0x51, 0x14, 0x1d, 0x00, 0x28, 0xc3, 0x66, 0x92, 0x2a, 0x53,
-	0x6b, 0x6f, 0xfa, 0x54, 0x59, 0x82, 0xa9, 0x35, 0x7e, 0x0f, 0x87, 0xdf, 0x65, 0xc5, 0xa8, 0x13,
-	0x7f, 0xa2, 0x25, 0x67, 0x82, 0xab, 0x4a, 0x57, 0xbc, 0x69, 0x2f, 0x36, 0x84, 0xdd, 0xe0, 0x4a,
-	0x83, 0xdc, 0x4f, 0xf8, 0x33, 0x1c, 0xf5, 0x4b, 0x7d, 0xfa, 0x04, 0x12, 0x71, 0xcf, 0x99, 0xdd,
-	0x90, 0x52, 0xf3, 0xd6, 0x21, 0xb6, 0xd8, 0xa5, 0x85, 0xf0, 0x14, 0x5e, 0x5c, 0x70, 0x26, 0x8c,
-	0xa6, 0x1f, 0xee, 0xa8, 0x24, 0xbf, 0xe8, 0xe6, 0xdc, 0x9d, 0x45, 0xee, 0x39, 0x0c, 0x57, 0x05,
-	0x3e, 0x6d, 0x04, 0x4f, 0x88, 0x83, 0xac, 0x24, 0xca, 0xdb, 0x11, 0x67, 0x80, 0x2e, 0xab, 0xe6,
-	0xfa, 0x2b, 0xf9, 0x53, 0xb1, 0xee, 0xc9, 0xfb, 0x12, 0xa6, 0xf0, 0x7c, 0x89, 0xdd, 0xd9, 0x33,
-	0x07, 0x79, 0x7e, 0x3b, 0xe2, 0x13, 0xd8, 0x2f, 0x6e, 0x0d, 0x91, 0x34, 0xe7, 0x5c, 0x6f, 0x73,
-	0x7f, 0x0b, 0x28, 0x24, 0x7b, 0xf3, 0x31, 0x80, 0x3b, 0xbf, 0x47, 0xfd, 0xf5, 0x03, 0xe4, 0xfc,
-	0xef, 0x00, 0xf6, 0x2f, 0x16, 0xbb, 0x53, 0x50, 0x79, 0x57, 0x95, 0x14, 0xbd, 0x83, 0x41, 0x61,
-	0x18, 0x1a, 0x9e, 0x06, 0x8b, 0xd6, 0xed, 0x54, 0xfa, 0x72, 0x0d, 0xf7, 0x69, 0xbf, 0x61, 0xd4,
-	0xd7, 0x1d, 0x3a, 0x09, 0x45, 0x5b, 0x96, 0x23, 0xcd, 0x1e, 0x46, 0x76, 0xb1, 0x67, 0x11, 0xfa,
-	0x09, 0x7b, 0xcb, 0xe5, 0xa1, 0x49, 0xe8, 0xb0, 0x71, 0x13, 0x52, 0xfc, 0x3f, 0x8a, 0xb3, 0xc6,
-	0x8f, 0x8e, 0x23, 0x74, 0x05, 0x71, 0xd0, 0x1b, 0x1a, 0x87, 0xb2, 0xf5, 0xfa, 0xd3, 0xc3, 0xde,
-	0xf3, 0xce, 0xf3, 0x2c, 0x42, 0x5f, 0x00, 0xba, 0xbe, 0xd0, 0xc1, 0xd2, 0x93, 0xae, 0x96, 0x9e,
-	0x8e, 0xfb, 0x8e, 0x9d, 0xe5, 0xc7, 0xbd, 0x1f, 0x49, 0xf8, 0x41, 0xcc, 0x77, 0xed, 0xb7, 0xf0,
-	0xe6, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x96, 0x6d, 0x29, 0xac, 0x46, 0x04, 0x00, 0x00,
+func (m *BigSumRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigSumRequest.Unmarshal(m, b)
 }
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// CalculatorServiceClient is the client API for CalculatorService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type CalculatorServiceClient interface {
-	// unary api
-	Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error)
-	// Stream
-	PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error)
-	// Client Stream
-	ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error)
-	// BI Stream
-	FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error)
-	SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error)
+func (m *BigSumRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigSumRequest.Marshal(b, m, deterministic)
 }
-
-type calculatorServiceClient struct {
-	cc *grpc.ClientConn
+func (m *BigSumRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigSumRequest.Merge(m, src)
 }
-
-func NewCalculatorServiceClient(cc *grpc.ClientConn) CalculatorServiceClient {
-	return &calculatorServiceClient{cc}
+func (m *BigSumRequest) XXX_Size() int {
+	return xxx_messageInfo_BigSumRequest.Size(m)
+}
+func (m *BigSumRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigSumRequest.DiscardUnknown(m)
 }
 
-func (c *calculatorServiceClient) Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error) {
-	out := new(SumResponse)
-	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Sum", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_BigSumRequest proto.InternalMessageInfo
+
+func (m *BigSumRequest) GetFirstNumber() string {
+	if m != nil {
+		return m.FirstNumber
 	}
-	return out, nil
+	return ""
 }
 
-func (c *calculatorServiceClient) PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[0], "/calculator.CalculatorService/PrimeNumberDecomposition", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &calculatorServicePrimeNumberDecompositionClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *BigSumRequest) GetSecondNumber() string {
+	if m != nil {
+		return m.SecondNumber
 	}
-	return x, nil
+	return ""
 }
 
-type CalculatorService_PrimeNumberDecompositionClient interface {
-	Recv() (*PrimeNumberDecompositionResponse, error)
-	grpc.ClientStream
+type BigSumResponse struct {
+	SumResult            string   `protobuf:"bytes,1,opt,name=sum_result,json=sumResult,proto3" json:"sum_result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type calculatorServicePrimeNumberDecompositionClient struct {
-	grpc.ClientStream
+func (m *BigSumResponse) Reset()         { *m = BigSumResponse{} }
+func (m *BigSumResponse) String() string { return proto.CompactTextString(m) }
+func (*BigSumResponse) ProtoMessage()    {}
+func (*BigSumResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{11}
 }
 
-func (x *calculatorServicePrimeNumberDecompositionClient) Recv() (*PrimeNumberDecompositionResponse, error) {
-	m := new(PrimeNumberDecompositionResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *BigSumResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigSumResponse.Unmarshal(m, b)
+}
+func (m *BigSumResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigSumResponse.Marshal(b, m, deterministic)
+}
+func (m *BigSumResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigSumResponse.Merge(m, src)
+}
+func (m *BigSumResponse) XXX_Size() int {
+	return xxx_messageInfo_BigSumResponse.Size(m)
+}
+func (m *BigSumResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigSumResponse.DiscardUnknown(m)
 }
 
-func (c *calculatorServiceClient) ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[1], "/calculator.CalculatorService/ComputeAverage", opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_BigSumResponse proto.InternalMessageInfo
+
+func (m *BigSumResponse) GetSumResult() string {
+	if m != nil {
+		return m.SumResult
 	}
-	x := &calculatorServiceComputeAverageClient{stream}
-	return x, nil
+	return ""
 }
 
-type CalculatorService_ComputeAverageClient interface {
-	Send(*ComputeAverageRequest) error
-	CloseAndRecv() (*ComputeAverageResponse, error)
-	grpc.ClientStream
+type BigComputeAverageRequest struct {
+	Number               string   `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type calculatorServiceComputeAverageClient struct {
-	grpc.ClientStream
+func (m *BigComputeAverageRequest) Reset()         { *m = BigComputeAverageRequest{} }
+func (m *BigComputeAverageRequest) String() string { return proto.CompactTextString(m) }
+func (*BigComputeAverageRequest) ProtoMessage()    {}
+func (*BigComputeAverageRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{12}
 }
 
-func (x *calculatorServiceComputeAverageClient) Send(m *ComputeAverageRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *BigComputeAverageRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigComputeAverageRequest.Unmarshal(m, b)
 }
-
-func (x *calculatorServiceComputeAverageClient) CloseAndRecv() (*ComputeAverageResponse, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(ComputeAverageResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *BigComputeAverageRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigComputeAverageRequest.Marshal(b, m, deterministic)
+}
+func (m *BigComputeAverageRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigComputeAverageRequest.Merge(m, src)
+}
+func (m *BigComputeAverageRequest) XXX_Size() int {
+	return xxx_messageInfo_BigComputeAverageRequest.Size(m)
+}
+func (m *BigComputeAverageRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigComputeAverageRequest.DiscardUnknown(m)
 }
 
-func (c *calculatorServiceClient) FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[2], "/calculator.CalculatorService/FindMaximum", opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_BigComputeAverageRequest proto.InternalMessageInfo
+
+func (m *BigComputeAverageRequest) GetNumber() string {
+	if m != nil {
+		return m.Number
 	}
-	x := &calculatorServiceFindMaximumClient{stream}
-	return x, nil
+	return ""
 }
 
-type CalculatorService_FindMaximumClient interface {
-	Send(*FindMaximumRequest) error
-	Recv() (*FindMaximumResponse, error)
-	grpc.ClientStream
+type BigComputeAverageResponse struct {
+	// exact when the average has a finite decimal expansion,
+	// otherwise rounded to 30 decimal places
+	Average              string   `protobuf:"bytes,1,opt,name=average,proto3" json:"average,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type calculatorServiceFindMaximumClient struct {
-	grpc.ClientStream
+func (m *BigComputeAverageResponse) Reset()         { *m = BigComputeAverageResponse{} }
+func (m *BigComputeAverageResponse) String() string { return proto.CompactTextString(m) }
+func (*BigComputeAverageResponse) ProtoMessage()    {}
+func (*BigComputeAverageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{13}
 }
 
-func (x *calculatorServiceFindMaximumClient) Send(m *FindMaximumRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *BigComputeAverageResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BigComputeAverageResponse.Unmarshal(m, b)
 }
-
-func (x *calculatorServiceFindMaximumClient) Recv() (*FindMaximumResponse, error) {
-	m := new(FindMaximumResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *BigComputeAverageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BigComputeAverageResponse.Marshal(b, m, deterministic)
+}
+func (m *BigComputeAverageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BigComputeAverageResponse.Merge(m, src)
+}
+func (m *BigComputeAverageResponse) XXX_Size() int {
+	return xxx_messageInfo_BigComputeAverageResponse.Size(m)
+}
+func (m *BigComputeAverageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BigComputeAverageResponse.DiscardUnknown(m)
 }
 
-func (c *calculatorServiceClient) SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error) {
-	out := new(SquareRootResponse)
-	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/SquareRoot", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_BigComputeAverageResponse proto.InternalMessageInfo
+
+func (m *BigComputeAverageResponse) GetAverage() string {
+	if m != nil {
+		return m.Average
 	}
-	return out, nil
+	return ""
 }
 
-// CalculatorServiceServer is the server API for CalculatorService service.
-type CalculatorServiceServer interface {
-	// unary api
-	Sum(context.Context, *SumRequest) (*SumResponse, error)
-	// Stream
-	PrimeNumberDecomposition(*PrimeNumberDecompositionRequest, CalculatorService_PrimeNumberDecompositionServer) error
-	// Client Stream
-	ComputeAverage(CalculatorService_ComputeAverageServer) error
-	// BI Stream
-	FindMaximum(CalculatorService_FindMaximumServer) error
-	SquareRoot(context.Context, *SquareRootRequest) (*SquareRootResponse, error)
+// Number is an operand or result of the arithmetic RPCs. Integer operands
+// give integer results where the operation allows it; mixing in a real
+// operand makes the result real, and mixing in a decimal makes it decimal.
+type Number struct {
+	// Types that are valid to be assigned to Value:
+	//	*Number_Integer
+	//	*Number_Real
+	//	*Number_Decimal
+	Value                isNumber_Value `protobuf_oneof:"value"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-// UnimplementedCalculatorServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedCalculatorServiceServer struct {
+func (m *Number) Reset()         { *m = Number{} }
+func (m *Number) String() string { return proto.CompactTextString(m) }
+func (*Number) ProtoMessage()    {}
+func (*Number) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{14}
 }
 
-func (*UnimplementedCalculatorServiceServer) Sum(ctx context.Context, req *SumRequest) (*SumResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Sum not implemented")
+func (m *Number) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Number.Unmarshal(m, b)
 }
-func (*UnimplementedCalculatorServiceServer) PrimeNumberDecomposition(req *PrimeNumberDecompositionRequest, srv CalculatorService_PrimeNumberDecompositionServer) error {
-	return status.Errorf(codes.Unimplemented, "method PrimeNumberDecomposition not implemented")
+func (m *Number) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Number.Marshal(b, m, deterministic)
 }
-func (*UnimplementedCalculatorServiceServer) ComputeAverage(srv CalculatorService_ComputeAverageServer) error {
-	return status.Errorf(codes.Unimplemented, "method ComputeAverage not implemented")
+func (m *Number) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Number.Merge(m, src)
 }
-func (*UnimplementedCalculatorServiceServer) FindMaximum(srv CalculatorService_FindMaximumServer) error {
-	return status.Errorf(codes.Unimplemented, "method FindMaximum not implemented")
+func (m *Number) XXX_Size() int {
+	return xxx_messageInfo_Number.Size(m)
 }
-func (*UnimplementedCalculatorServiceServer) SquareRoot(ctx context.Context, req *SquareRootRequest) (*SquareRootResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SquareRoot not implemented")
+func (m *Number) XXX_DiscardUnknown() {
+	xxx_messageInfo_Number.DiscardUnknown(m)
 }
 
-func RegisterCalculatorServiceServer(s *grpc.Server, srv CalculatorServiceServer) {
-	s.RegisterService(&_CalculatorService_serviceDesc, srv)
-}
+var xxx_messageInfo_Number proto.InternalMessageInfo
 
-func _CalculatorService_Sum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SumRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(CalculatorServiceServer).Sum(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/calculator.CalculatorService/Sum",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalculatorServiceServer).Sum(ctx, req.(*SumRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+type isNumber_Value interface {
+	isNumber_Value()
 }
 
-func _CalculatorService_PrimeNumberDecomposition_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(PrimeNumberDecompositionRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(CalculatorServiceServer).PrimeNumberDecomposition(m, &calculatorServicePrimeNumberDecompositionServer{stream})
+type Number_Integer struct {
+	Integer int64 `protobuf:"varint,1,opt,name=integer,proto3,oneof"`
 }
 
-type CalculatorService_PrimeNumberDecompositionServer interface {
-	Send(*PrimeNumberDecompositionResponse) error
-	grpc.ServerStream
+type Number_Real struct {
+	Real float64 `protobuf:"fixed64,2,opt,name=real,proto3,oneof"`
 }
 
-type calculatorServicePrimeNumberDecompositionServer struct {
-	grpc.ServerStream
+type Number_Decimal struct {
+	Decimal string `protobuf:"bytes,3,opt,name=decimal,proto3,oneof"`
 }
 
-func (x *calculatorServicePrimeNumberDecompositionServer) Send(m *PrimeNumberDecompositionResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (*Number_Integer) isNumber_Value() {}
+
+func (*Number_Real) isNumber_Value() {}
+
+func (*Number_Decimal) isNumber_Value() {}
+
+func (m *Number) GetValue() isNumber_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
 }
 
-func _CalculatorService_ComputeAverage_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(CalculatorServiceServer).ComputeAverage(&calculatorServiceComputeAverageServer{stream})
+func (m *Number) GetInteger() int64 {
+	if x, ok := m.GetValue().(*Number_Integer); ok {
+		return x.Integer
+	}
+	return 0
 }
 
-type CalculatorService_ComputeAverageServer interface {
-	SendAndClose(*ComputeAverageResponse) error
-	Recv() (*ComputeAverageRequest, error)
-	grpc.ServerStream
+func (m *Number) GetReal() float64 {
+	if x, ok := m.GetValue().(*Number_Real); ok {
+		return x.Real
+	}
+	return 0
 }
 
-type calculatorServiceComputeAverageServer struct {
-	grpc.ServerStream
+func (m *Number) GetDecimal() string {
+	if x, ok := m.GetValue().(*Number_Decimal); ok {
+		return x.Decimal
+	}
+	return ""
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Number) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Number_Integer)(nil),
+		(*Number_Real)(nil),
+		(*Number_Decimal)(nil),
+	}
+}
+
+type BinaryOperationRequest struct {
+	First                *Number  `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second               *Number  `protobuf:"bytes,2,opt,name=second,proto3" json:"second,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BinaryOperationRequest) Reset()         { *m = BinaryOperationRequest{} }
+func (m *BinaryOperationRequest) String() string { return proto.CompactTextString(m) }
+func (*BinaryOperationRequest) ProtoMessage()    {}
+func (*BinaryOperationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{15}
+}
+
+func (m *BinaryOperationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BinaryOperationRequest.Unmarshal(m, b)
+}
+func (m *BinaryOperationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BinaryOperationRequest.Marshal(b, m, deterministic)
+}
+func (m *BinaryOperationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BinaryOperationRequest.Merge(m, src)
+}
+func (m *BinaryOperationRequest) XXX_Size() int {
+	return xxx_messageInfo_BinaryOperationRequest.Size(m)
+}
+func (m *BinaryOperationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BinaryOperationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BinaryOperationRequest proto.InternalMessageInfo
+
+func (m *BinaryOperationRequest) GetFirst() *Number {
+	if m != nil {
+		return m.First
+	}
+	return nil
+}
+
+func (m *BinaryOperationRequest) GetSecond() *Number {
+	if m != nil {
+		return m.Second
+	}
+	return nil
+}
+
+type NumberResponse struct {
+	Result               *Number  `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NumberResponse) Reset()         { *m = NumberResponse{} }
+func (m *NumberResponse) String() string { return proto.CompactTextString(m) }
+func (*NumberResponse) ProtoMessage()    {}
+func (*NumberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{16}
+}
+
+func (m *NumberResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NumberResponse.Unmarshal(m, b)
+}
+func (m *NumberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NumberResponse.Marshal(b, m, deterministic)
+}
+func (m *NumberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NumberResponse.Merge(m, src)
+}
+func (m *NumberResponse) XXX_Size() int {
+	return xxx_messageInfo_NumberResponse.Size(m)
+}
+func (m *NumberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NumberResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NumberResponse proto.InternalMessageInfo
+
+func (m *NumberResponse) GetResult() *Number {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type FactorialRequest struct {
+	Number               *Number  `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FactorialRequest) Reset()         { *m = FactorialRequest{} }
+func (m *FactorialRequest) String() string { return proto.CompactTextString(m) }
+func (*FactorialRequest) ProtoMessage()    {}
+func (*FactorialRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{17}
+}
+
+func (m *FactorialRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FactorialRequest.Unmarshal(m, b)
+}
+func (m *FactorialRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FactorialRequest.Marshal(b, m, deterministic)
+}
+func (m *FactorialRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FactorialRequest.Merge(m, src)
+}
+func (m *FactorialRequest) XXX_Size() int {
+	return xxx_messageInfo_FactorialRequest.Size(m)
+}
+func (m *FactorialRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FactorialRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FactorialRequest proto.InternalMessageInfo
+
+func (m *FactorialRequest) GetNumber() *Number {
+	if m != nil {
+		return m.Number
+	}
+	return nil
+}
+
+type FactorialResponse struct {
+	// decimal, since factorials beyond 20! do not fit in int64
+	Result               string   `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FactorialResponse) Reset()         { *m = FactorialResponse{} }
+func (m *FactorialResponse) String() string { return proto.CompactTextString(m) }
+func (*FactorialResponse) ProtoMessage()    {}
+func (*FactorialResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{18}
+}
+
+func (m *FactorialResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FactorialResponse.Unmarshal(m, b)
+}
+func (m *FactorialResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FactorialResponse.Marshal(b, m, deterministic)
+}
+func (m *FactorialResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FactorialResponse.Merge(m, src)
+}
+func (m *FactorialResponse) XXX_Size() int {
+	return xxx_messageInfo_FactorialResponse.Size(m)
+}
+func (m *FactorialResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FactorialResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FactorialResponse proto.InternalMessageInfo
+
+func (m *FactorialResponse) GetResult() string {
+	if m != nil {
+		return m.Result
+	}
+	return ""
+}
+
+type EvaluateRequest struct {
+	// infix expression such as "2 * sin(x) ^ 2 - -1", with + - * / % ^,
+	// parentheses, the constants pi and e and functions like sqrt and log
+	Expression string `protobuf:"bytes,1,opt,name=expression,proto3" json:"expression,omitempty"`
+	// values of the variables the expression uses
+	Variables            map[string]float64 `protobuf:"bytes,2,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *EvaluateRequest) Reset()         { *m = EvaluateRequest{} }
+func (m *EvaluateRequest) String() string { return proto.CompactTextString(m) }
+func (*EvaluateRequest) ProtoMessage()    {}
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{19}
+}
+
+func (m *EvaluateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateRequest.Unmarshal(m, b)
+}
+func (m *EvaluateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateRequest.Marshal(b, m, deterministic)
+}
+func (m *EvaluateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateRequest.Merge(m, src)
+}
+func (m *EvaluateRequest) XXX_Size() int {
+	return xxx_messageInfo_EvaluateRequest.Size(m)
+}
+func (m *EvaluateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateRequest proto.InternalMessageInfo
+
+func (m *EvaluateRequest) GetExpression() string {
+	if m != nil {
+		return m.Expression
+	}
+	return ""
+}
+
+func (m *EvaluateRequest) GetVariables() map[string]float64 {
+	if m != nil {
+		return m.Variables
+	}
+	return nil
+}
+
+type EvaluateResponse struct {
+	Result               float64  `protobuf:"fixed64,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvaluateResponse) Reset()         { *m = EvaluateResponse{} }
+func (m *EvaluateResponse) String() string { return proto.CompactTextString(m) }
+func (*EvaluateResponse) ProtoMessage()    {}
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{20}
+}
+
+func (m *EvaluateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvaluateResponse.Unmarshal(m, b)
+}
+func (m *EvaluateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvaluateResponse.Marshal(b, m, deterministic)
+}
+func (m *EvaluateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvaluateResponse.Merge(m, src)
+}
+func (m *EvaluateResponse) XXX_Size() int {
+	return xxx_messageInfo_EvaluateResponse.Size(m)
+}
+func (m *EvaluateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvaluateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvaluateResponse proto.InternalMessageInfo
+
+func (m *EvaluateResponse) GetResult() float64 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+type IsPrimeRequest struct {
+	Number               int64    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IsPrimeRequest) Reset()         { *m = IsPrimeRequest{} }
+func (m *IsPrimeRequest) String() string { return proto.CompactTextString(m) }
+func (*IsPrimeRequest) ProtoMessage()    {}
+func (*IsPrimeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{21}
+}
+
+func (m *IsPrimeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IsPrimeRequest.Unmarshal(m, b)
+}
+func (m *IsPrimeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IsPrimeRequest.Marshal(b, m, deterministic)
+}
+func (m *IsPrimeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IsPrimeRequest.Merge(m, src)
+}
+func (m *IsPrimeRequest) XXX_Size() int {
+	return xxx_messageInfo_IsPrimeRequest.Size(m)
+}
+func (m *IsPrimeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_IsPrimeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IsPrimeRequest proto.InternalMessageInfo
+
+func (m *IsPrimeRequest) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+type IsPrimeResponse struct {
+	IsPrime              bool     `protobuf:"varint,1,opt,name=is_prime,json=isPrime,proto3" json:"is_prime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IsPrimeResponse) Reset()         { *m = IsPrimeResponse{} }
+func (m *IsPrimeResponse) String() string { return proto.CompactTextString(m) }
+func (*IsPrimeResponse) ProtoMessage()    {}
+func (*IsPrimeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{22}
+}
+
+func (m *IsPrimeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IsPrimeResponse.Unmarshal(m, b)
+}
+func (m *IsPrimeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IsPrimeResponse.Marshal(b, m, deterministic)
+}
+func (m *IsPrimeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IsPrimeResponse.Merge(m, src)
+}
+func (m *IsPrimeResponse) XXX_Size() int {
+	return xxx_messageInfo_IsPrimeResponse.Size(m)
+}
+func (m *IsPrimeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_IsPrimeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IsPrimeResponse proto.InternalMessageInfo
+
+func (m *IsPrimeResponse) GetIsPrime() bool {
+	if m != nil {
+		return m.IsPrime
+	}
+	return false
+}
+
+type NextPrimeRequest struct {
+	Number               int64    `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NextPrimeRequest) Reset()         { *m = NextPrimeRequest{} }
+func (m *NextPrimeRequest) String() string { return proto.CompactTextString(m) }
+func (*NextPrimeRequest) ProtoMessage()    {}
+func (*NextPrimeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{23}
+}
+
+func (m *NextPrimeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NextPrimeRequest.Unmarshal(m, b)
+}
+func (m *NextPrimeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NextPrimeRequest.Marshal(b, m, deterministic)
+}
+func (m *NextPrimeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NextPrimeRequest.Merge(m, src)
+}
+func (m *NextPrimeRequest) XXX_Size() int {
+	return xxx_messageInfo_NextPrimeRequest.Size(m)
+}
+func (m *NextPrimeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NextPrimeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NextPrimeRequest proto.InternalMessageInfo
+
+func (m *NextPrimeRequest) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+type NextPrimeResponse struct {
+	Prime                int64    `protobuf:"varint,1,opt,name=prime,proto3" json:"prime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NextPrimeResponse) Reset()         { *m = NextPrimeResponse{} }
+func (m *NextPrimeResponse) String() string { return proto.CompactTextString(m) }
+func (*NextPrimeResponse) ProtoMessage()    {}
+func (*NextPrimeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{24}
+}
+
+func (m *NextPrimeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NextPrimeResponse.Unmarshal(m, b)
+}
+func (m *NextPrimeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NextPrimeResponse.Marshal(b, m, deterministic)
+}
+func (m *NextPrimeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NextPrimeResponse.Merge(m, src)
+}
+func (m *NextPrimeResponse) XXX_Size() int {
+	return xxx_messageInfo_NextPrimeResponse.Size(m)
+}
+func (m *NextPrimeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NextPrimeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NextPrimeResponse proto.InternalMessageInfo
+
+func (m *NextPrimeResponse) GetPrime() int64 {
+	if m != nil {
+		return m.Prime
+	}
+	return 0
+}
+
+type PrimesInRangeRequest struct {
+	// inclusive bounds
+	From                 int64    `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   int64    `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrimesInRangeRequest) Reset()         { *m = PrimesInRangeRequest{} }
+func (m *PrimesInRangeRequest) String() string { return proto.CompactTextString(m) }
+func (*PrimesInRangeRequest) ProtoMessage()    {}
+func (*PrimesInRangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{25}
+}
+
+func (m *PrimesInRangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrimesInRangeRequest.Unmarshal(m, b)
+}
+func (m *PrimesInRangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrimesInRangeRequest.Marshal(b, m, deterministic)
+}
+func (m *PrimesInRangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrimesInRangeRequest.Merge(m, src)
+}
+func (m *PrimesInRangeRequest) XXX_Size() int {
+	return xxx_messageInfo_PrimesInRangeRequest.Size(m)
+}
+func (m *PrimesInRangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrimesInRangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrimesInRangeRequest proto.InternalMessageInfo
+
+func (m *PrimesInRangeRequest) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *PrimesInRangeRequest) GetTo() int64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+type PrimesInRangeResponse struct {
+	// the next primes in ascending order, a batch per message
+	Primes               []int64  `protobuf:"varint,1,rep,packed,name=primes,proto3" json:"primes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrimesInRangeResponse) Reset()         { *m = PrimesInRangeResponse{} }
+func (m *PrimesInRangeResponse) String() string { return proto.CompactTextString(m) }
+func (*PrimesInRangeResponse) ProtoMessage()    {}
+func (*PrimesInRangeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{26}
+}
+
+func (m *PrimesInRangeResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrimesInRangeResponse.Unmarshal(m, b)
+}
+func (m *PrimesInRangeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrimesInRangeResponse.Marshal(b, m, deterministic)
+}
+func (m *PrimesInRangeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrimesInRangeResponse.Merge(m, src)
+}
+func (m *PrimesInRangeResponse) XXX_Size() int {
+	return xxx_messageInfo_PrimesInRangeResponse.Size(m)
+}
+func (m *PrimesInRangeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrimesInRangeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrimesInRangeResponse proto.InternalMessageInfo
+
+func (m *PrimesInRangeResponse) GetPrimes() []int64 {
+	if m != nil {
+		return m.Primes
+	}
+	return nil
+}
+
+type ComputeStatisticsRequest struct {
+	Number float64 `protobuf:"fixed64,1,opt,name=number,proto3" json:"number,omitempty"`
+	// percentiles to report, each between 0 and 100; only read from the
+	// first message, the server's defaults apply when it has none
+	Percentiles          []float64 `protobuf:"fixed64,2,rep,packed,name=percentiles,proto3" json:"percentiles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ComputeStatisticsRequest) Reset()         { *m = ComputeStatisticsRequest{} }
+func (m *ComputeStatisticsRequest) String() string { return proto.CompactTextString(m) }
+func (*ComputeStatisticsRequest) ProtoMessage()    {}
+func (*ComputeStatisticsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{27}
+}
+
+func (m *ComputeStatisticsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComputeStatisticsRequest.Unmarshal(m, b)
+}
+func (m *ComputeStatisticsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComputeStatisticsRequest.Marshal(b, m, deterministic)
+}
+func (m *ComputeStatisticsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComputeStatisticsRequest.Merge(m, src)
+}
+func (m *ComputeStatisticsRequest) XXX_Size() int {
+	return xxx_messageInfo_ComputeStatisticsRequest.Size(m)
+}
+func (m *ComputeStatisticsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComputeStatisticsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComputeStatisticsRequest proto.InternalMessageInfo
+
+func (m *ComputeStatisticsRequest) GetNumber() float64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsRequest) GetPercentiles() []float64 {
+	if m != nil {
+		return m.Percentiles
+	}
+	return nil
+}
+
+type Percentile struct {
+	Percentile           float64  `protobuf:"fixed64,1,opt,name=percentile,proto3" json:"percentile,omitempty"`
+	Value                float64  `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Percentile) Reset()         { *m = Percentile{} }
+func (m *Percentile) String() string { return proto.CompactTextString(m) }
+func (*Percentile) ProtoMessage()    {}
+func (*Percentile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{28}
+}
+
+func (m *Percentile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Percentile.Unmarshal(m, b)
+}
+func (m *Percentile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Percentile.Marshal(b, m, deterministic)
+}
+func (m *Percentile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Percentile.Merge(m, src)
+}
+func (m *Percentile) XXX_Size() int {
+	return xxx_messageInfo_Percentile.Size(m)
+}
+func (m *Percentile) XXX_DiscardUnknown() {
+	xxx_messageInfo_Percentile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Percentile proto.InternalMessageInfo
+
+func (m *Percentile) GetPercentile() float64 {
+	if m != nil {
+		return m.Percentile
+	}
+	return 0
+}
+
+func (m *Percentile) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+type ComputeStatisticsResponse struct {
+	Count int64   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Sum   float64 `protobuf:"fixed64,2,opt,name=sum,proto3" json:"sum,omitempty"`
+	Mean  float64 `protobuf:"fixed64,3,opt,name=mean,proto3" json:"mean,omitempty"`
+	Min   float64 `protobuf:"fixed64,4,opt,name=min,proto3" json:"min,omitempty"`
+	Max   float64 `protobuf:"fixed64,5,opt,name=max,proto3" json:"max,omitempty"`
+	// sample variance, 0 for a single number
+	Variance          float64 `protobuf:"fixed64,6,opt,name=variance,proto3" json:"variance,omitempty"`
+	StandardDeviation float64 `protobuf:"fixed64,7,opt,name=standard_deviation,json=standardDeviation,proto3" json:"standard_deviation,omitempty"`
+	// the median and percentiles are estimated with a t-digest, they are
+	// exact for short streams and very close for long ones
+	Median               float64       `protobuf:"fixed64,8,opt,name=median,proto3" json:"median,omitempty"`
+	Percentiles          []*Percentile `protobuf:"bytes,9,rep,name=percentiles,proto3" json:"percentiles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ComputeStatisticsResponse) Reset()         { *m = ComputeStatisticsResponse{} }
+func (m *ComputeStatisticsResponse) String() string { return proto.CompactTextString(m) }
+func (*ComputeStatisticsResponse) ProtoMessage()    {}
+func (*ComputeStatisticsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{29}
+}
+
+func (m *ComputeStatisticsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ComputeStatisticsResponse.Unmarshal(m, b)
+}
+func (m *ComputeStatisticsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ComputeStatisticsResponse.Marshal(b, m, deterministic)
+}
+func (m *ComputeStatisticsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ComputeStatisticsResponse.Merge(m, src)
+}
+func (m *ComputeStatisticsResponse) XXX_Size() int {
+	return xxx_messageInfo_ComputeStatisticsResponse.Size(m)
+}
+func (m *ComputeStatisticsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ComputeStatisticsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ComputeStatisticsResponse proto.InternalMessageInfo
+
+func (m *ComputeStatisticsResponse) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetSum() float64 {
+	if m != nil {
+		return m.Sum
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetMean() float64 {
+	if m != nil {
+		return m.Mean
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetMin() float64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetMax() float64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetVariance() float64 {
+	if m != nil {
+		return m.Variance
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetStandardDeviation() float64 {
+	if m != nil {
+		return m.StandardDeviation
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetMedian() float64 {
+	if m != nil {
+		return m.Median
+	}
+	return 0
+}
+
+func (m *ComputeStatisticsResponse) GetPercentiles() []*Percentile {
+	if m != nil {
+		return m.Percentiles
+	}
+	return nil
+}
+
+// Window selects the numbers an aggregate covers. Without a count or
+// duration it covers every number sent so far.
+type Window struct {
+	// Types that are valid to be assigned to Size:
+	//	*Window_Count
+	//	*Window_DurationMs
+	Size isWindow_Size `protobuf_oneof:"size"`
+	// a sliding window reports after every number, a tumbling window
+	// reports once when it closes and then starts empty; the last, partial
+	// tumbling window is reported when the client closes the stream
+	Tumbling             bool     `protobuf:"varint,3,opt,name=tumbling,proto3" json:"tumbling,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Window) Reset()         { *m = Window{} }
+func (m *Window) String() string { return proto.CompactTextString(m) }
+func (*Window) ProtoMessage()    {}
+func (*Window) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{30}
+}
+
+func (m *Window) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Window.Unmarshal(m, b)
+}
+func (m *Window) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Window.Marshal(b, m, deterministic)
+}
+func (m *Window) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Window.Merge(m, src)
+}
+func (m *Window) XXX_Size() int {
+	return xxx_messageInfo_Window.Size(m)
+}
+func (m *Window) XXX_DiscardUnknown() {
+	xxx_messageInfo_Window.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Window proto.InternalMessageInfo
+
+type isWindow_Size interface {
+	isWindow_Size()
+}
+
+type Window_Count struct {
+	Count uint32 `protobuf:"varint,1,opt,name=count,proto3,oneof"`
+}
+
+type Window_DurationMs struct {
+	DurationMs int64 `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3,oneof"`
+}
+
+func (*Window_Count) isWindow_Size() {}
+
+func (*Window_DurationMs) isWindow_Size() {}
+
+func (m *Window) GetSize() isWindow_Size {
+	if m != nil {
+		return m.Size
+	}
+	return nil
+}
+
+func (m *Window) GetCount() uint32 {
+	if x, ok := m.GetSize().(*Window_Count); ok {
+		return x.Count
+	}
+	return 0
+}
+
+func (m *Window) GetDurationMs() int64 {
+	if x, ok := m.GetSize().(*Window_DurationMs); ok {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (m *Window) GetTumbling() bool {
+	if m != nil {
+		return m.Tumbling
+	}
+	return false
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Window) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Window_Count)(nil),
+		(*Window_DurationMs)(nil),
+	}
+}
+
+type RunningAggregateRequest struct {
+	// aggregate and window are only read from the first message
+	Aggregate            Aggregate `protobuf:"varint,1,opt,name=aggregate,proto3,enum=calculator.Aggregate" json:"aggregate,omitempty"`
+	Window               *Window   `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	Number               float64   `protobuf:"fixed64,3,opt,name=number,proto3" json:"number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *RunningAggregateRequest) Reset()         { *m = RunningAggregateRequest{} }
+func (m *RunningAggregateRequest) String() string { return proto.CompactTextString(m) }
+func (*RunningAggregateRequest) ProtoMessage()    {}
+func (*RunningAggregateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{31}
+}
+
+func (m *RunningAggregateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunningAggregateRequest.Unmarshal(m, b)
+}
+func (m *RunningAggregateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunningAggregateRequest.Marshal(b, m, deterministic)
+}
+func (m *RunningAggregateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunningAggregateRequest.Merge(m, src)
+}
+func (m *RunningAggregateRequest) XXX_Size() int {
+	return xxx_messageInfo_RunningAggregateRequest.Size(m)
+}
+func (m *RunningAggregateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunningAggregateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunningAggregateRequest proto.InternalMessageInfo
+
+func (m *RunningAggregateRequest) GetAggregate() Aggregate {
+	if m != nil {
+		return m.Aggregate
+	}
+	return Aggregate_AGGREGATE_UNSPECIFIED
+}
+
+func (m *RunningAggregateRequest) GetWindow() *Window {
+	if m != nil {
+		return m.Window
+	}
+	return nil
+}
+
+func (m *RunningAggregateRequest) GetNumber() float64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+type RunningAggregateResponse struct {
+	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	// how many numbers the value covers
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RunningAggregateResponse) Reset()         { *m = RunningAggregateResponse{} }
+func (m *RunningAggregateResponse) String() string { return proto.CompactTextString(m) }
+func (*RunningAggregateResponse) ProtoMessage()    {}
+func (*RunningAggregateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{32}
+}
+
+func (m *RunningAggregateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunningAggregateResponse.Unmarshal(m, b)
+}
+func (m *RunningAggregateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunningAggregateResponse.Marshal(b, m, deterministic)
+}
+func (m *RunningAggregateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunningAggregateResponse.Merge(m, src)
+}
+func (m *RunningAggregateResponse) XXX_Size() int {
+	return xxx_messageInfo_RunningAggregateResponse.Size(m)
+}
+func (m *RunningAggregateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunningAggregateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RunningAggregateResponse proto.InternalMessageInfo
+
+func (m *RunningAggregateResponse) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *RunningAggregateResponse) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// Matrix holds its values row by row, so values[i * cols + j] is the
+// value in row i, column j
+type Matrix struct {
+	Rows                 int32     `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Cols                 int32     `protobuf:"varint,2,opt,name=cols,proto3" json:"cols,omitempty"`
+	Values               []float64 `protobuf:"fixed64,3,rep,packed,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Matrix) Reset()         { *m = Matrix{} }
+func (m *Matrix) String() string { return proto.CompactTextString(m) }
+func (*Matrix) ProtoMessage()    {}
+func (*Matrix) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{33}
+}
+
+func (m *Matrix) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Matrix.Unmarshal(m, b)
+}
+func (m *Matrix) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Matrix.Marshal(b, m, deterministic)
+}
+func (m *Matrix) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Matrix.Merge(m, src)
+}
+func (m *Matrix) XXX_Size() int {
+	return xxx_messageInfo_Matrix.Size(m)
+}
+func (m *Matrix) XXX_DiscardUnknown() {
+	xxx_messageInfo_Matrix.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Matrix proto.InternalMessageInfo
+
+func (m *Matrix) GetRows() int32 {
+	if m != nil {
+		return m.Rows
+	}
+	return 0
+}
+
+func (m *Matrix) GetCols() int32 {
+	if m != nil {
+		return m.Cols
+	}
+	return 0
+}
+
+func (m *Matrix) GetValues() []float64 {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type MatrixRequest struct {
+	Matrix               *Matrix  `protobuf:"bytes,1,opt,name=matrix,proto3" json:"matrix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MatrixRequest) Reset()         { *m = MatrixRequest{} }
+func (m *MatrixRequest) String() string { return proto.CompactTextString(m) }
+func (*MatrixRequest) ProtoMessage()    {}
+func (*MatrixRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{34}
+}
+
+func (m *MatrixRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatrixRequest.Unmarshal(m, b)
+}
+func (m *MatrixRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatrixRequest.Marshal(b, m, deterministic)
+}
+func (m *MatrixRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatrixRequest.Merge(m, src)
+}
+func (m *MatrixRequest) XXX_Size() int {
+	return xxx_messageInfo_MatrixRequest.Size(m)
+}
+func (m *MatrixRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatrixRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatrixRequest proto.InternalMessageInfo
+
+func (m *MatrixRequest) GetMatrix() *Matrix {
+	if m != nil {
+		return m.Matrix
+	}
+	return nil
+}
+
+type MatrixPairRequest struct {
+	First                *Matrix  `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second               *Matrix  `protobuf:"bytes,2,opt,name=second,proto3" json:"second,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MatrixPairRequest) Reset()         { *m = MatrixPairRequest{} }
+func (m *MatrixPairRequest) String() string { return proto.CompactTextString(m) }
+func (*MatrixPairRequest) ProtoMessage()    {}
+func (*MatrixPairRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{35}
+}
+
+func (m *MatrixPairRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatrixPairRequest.Unmarshal(m, b)
+}
+func (m *MatrixPairRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatrixPairRequest.Marshal(b, m, deterministic)
+}
+func (m *MatrixPairRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatrixPairRequest.Merge(m, src)
+}
+func (m *MatrixPairRequest) XXX_Size() int {
+	return xxx_messageInfo_MatrixPairRequest.Size(m)
+}
+func (m *MatrixPairRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatrixPairRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatrixPairRequest proto.InternalMessageInfo
+
+func (m *MatrixPairRequest) GetFirst() *Matrix {
+	if m != nil {
+		return m.First
+	}
+	return nil
+}
+
+func (m *MatrixPairRequest) GetSecond() *Matrix {
+	if m != nil {
+		return m.Second
+	}
+	return nil
+}
+
+type MatrixResponse struct {
+	Result               *Matrix  `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MatrixResponse) Reset()         { *m = MatrixResponse{} }
+func (m *MatrixResponse) String() string { return proto.CompactTextString(m) }
+func (*MatrixResponse) ProtoMessage()    {}
+func (*MatrixResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{36}
+}
+
+func (m *MatrixResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatrixResponse.Unmarshal(m, b)
+}
+func (m *MatrixResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatrixResponse.Marshal(b, m, deterministic)
+}
+func (m *MatrixResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatrixResponse.Merge(m, src)
+}
+func (m *MatrixResponse) XXX_Size() int {
+	return xxx_messageInfo_MatrixResponse.Size(m)
+}
+func (m *MatrixResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatrixResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatrixResponse proto.InternalMessageInfo
+
+func (m *MatrixResponse) GetResult() *Matrix {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type DeterminantResponse struct {
+	Determinant          float64  `protobuf:"fixed64,1,opt,name=determinant,proto3" json:"determinant,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeterminantResponse) Reset()         { *m = DeterminantResponse{} }
+func (m *DeterminantResponse) String() string { return proto.CompactTextString(m) }
+func (*DeterminantResponse) ProtoMessage()    {}
+func (*DeterminantResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{37}
+}
+
+func (m *DeterminantResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeterminantResponse.Unmarshal(m, b)
+}
+func (m *DeterminantResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeterminantResponse.Marshal(b, m, deterministic)
+}
+func (m *DeterminantResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeterminantResponse.Merge(m, src)
+}
+func (m *DeterminantResponse) XXX_Size() int {
+	return xxx_messageInfo_DeterminantResponse.Size(m)
+}
+func (m *DeterminantResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeterminantResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeterminantResponse proto.InternalMessageInfo
+
+func (m *DeterminantResponse) GetDeterminant() float64 {
+	if m != nil {
+		return m.Determinant
+	}
+	return 0
+}
+
+// SolveLinearSystemRequest is the system coefficients * x = constants
+type SolveLinearSystemRequest struct {
+	Coefficients         *Matrix   `protobuf:"bytes,1,opt,name=coefficients,proto3" json:"coefficients,omitempty"`
+	Constants            []float64 `protobuf:"fixed64,2,rep,packed,name=constants,proto3" json:"constants,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SolveLinearSystemRequest) Reset()         { *m = SolveLinearSystemRequest{} }
+func (m *SolveLinearSystemRequest) String() string { return proto.CompactTextString(m) }
+func (*SolveLinearSystemRequest) ProtoMessage()    {}
+func (*SolveLinearSystemRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{38}
+}
+
+func (m *SolveLinearSystemRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SolveLinearSystemRequest.Unmarshal(m, b)
+}
+func (m *SolveLinearSystemRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SolveLinearSystemRequest.Marshal(b, m, deterministic)
+}
+func (m *SolveLinearSystemRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SolveLinearSystemRequest.Merge(m, src)
+}
+func (m *SolveLinearSystemRequest) XXX_Size() int {
+	return xxx_messageInfo_SolveLinearSystemRequest.Size(m)
+}
+func (m *SolveLinearSystemRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SolveLinearSystemRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SolveLinearSystemRequest proto.InternalMessageInfo
+
+func (m *SolveLinearSystemRequest) GetCoefficients() *Matrix {
+	if m != nil {
+		return m.Coefficients
+	}
+	return nil
+}
+
+func (m *SolveLinearSystemRequest) GetConstants() []float64 {
+	if m != nil {
+		return m.Constants
+	}
+	return nil
+}
+
+type SolveLinearSystemResponse struct {
+	Solution             []float64 `protobuf:"fixed64,1,rep,packed,name=solution,proto3" json:"solution,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *SolveLinearSystemResponse) Reset()         { *m = SolveLinearSystemResponse{} }
+func (m *SolveLinearSystemResponse) String() string { return proto.CompactTextString(m) }
+func (*SolveLinearSystemResponse) ProtoMessage()    {}
+func (*SolveLinearSystemResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{39}
+}
+
+func (m *SolveLinearSystemResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SolveLinearSystemResponse.Unmarshal(m, b)
+}
+func (m *SolveLinearSystemResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SolveLinearSystemResponse.Marshal(b, m, deterministic)
+}
+func (m *SolveLinearSystemResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SolveLinearSystemResponse.Merge(m, src)
+}
+func (m *SolveLinearSystemResponse) XXX_Size() int {
+	return xxx_messageInfo_SolveLinearSystemResponse.Size(m)
+}
+func (m *SolveLinearSystemResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SolveLinearSystemResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SolveLinearSystemResponse proto.InternalMessageInfo
+
+func (m *SolveLinearSystemResponse) GetSolution() []float64 {
+	if m != nil {
+		return m.Solution
+	}
+	return nil
+}
+
+type Vector struct {
+	Values               []float64 `protobuf:"fixed64,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Vector) Reset()         { *m = Vector{} }
+func (m *Vector) String() string { return proto.CompactTextString(m) }
+func (*Vector) ProtoMessage()    {}
+func (*Vector) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{40}
+}
+
+func (m *Vector) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Vector.Unmarshal(m, b)
+}
+func (m *Vector) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Vector.Marshal(b, m, deterministic)
+}
+func (m *Vector) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Vector.Merge(m, src)
+}
+func (m *Vector) XXX_Size() int {
+	return xxx_messageInfo_Vector.Size(m)
+}
+func (m *Vector) XXX_DiscardUnknown() {
+	xxx_messageInfo_Vector.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Vector proto.InternalMessageInfo
+
+func (m *Vector) GetValues() []float64 {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type VectorRequest struct {
+	Vector               *Vector  `protobuf:"bytes,1,opt,name=vector,proto3" json:"vector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VectorRequest) Reset()         { *m = VectorRequest{} }
+func (m *VectorRequest) String() string { return proto.CompactTextString(m) }
+func (*VectorRequest) ProtoMessage()    {}
+func (*VectorRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{41}
+}
+
+func (m *VectorRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VectorRequest.Unmarshal(m, b)
+}
+func (m *VectorRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VectorRequest.Marshal(b, m, deterministic)
+}
+func (m *VectorRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VectorRequest.Merge(m, src)
+}
+func (m *VectorRequest) XXX_Size() int {
+	return xxx_messageInfo_VectorRequest.Size(m)
+}
+func (m *VectorRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VectorRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VectorRequest proto.InternalMessageInfo
+
+func (m *VectorRequest) GetVector() *Vector {
+	if m != nil {
+		return m.Vector
+	}
+	return nil
+}
+
+type VectorPairRequest struct {
+	First                *Vector  `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second               *Vector  `protobuf:"bytes,2,opt,name=second,proto3" json:"second,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VectorPairRequest) Reset()         { *m = VectorPairRequest{} }
+func (m *VectorPairRequest) String() string { return proto.CompactTextString(m) }
+func (*VectorPairRequest) ProtoMessage()    {}
+func (*VectorPairRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{42}
+}
+
+func (m *VectorPairRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VectorPairRequest.Unmarshal(m, b)
+}
+func (m *VectorPairRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VectorPairRequest.Marshal(b, m, deterministic)
+}
+func (m *VectorPairRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VectorPairRequest.Merge(m, src)
+}
+func (m *VectorPairRequest) XXX_Size() int {
+	return xxx_messageInfo_VectorPairRequest.Size(m)
+}
+func (m *VectorPairRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VectorPairRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VectorPairRequest proto.InternalMessageInfo
+
+func (m *VectorPairRequest) GetFirst() *Vector {
+	if m != nil {
+		return m.First
+	}
+	return nil
+}
+
+func (m *VectorPairRequest) GetSecond() *Vector {
+	if m != nil {
+		return m.Second
+	}
+	return nil
+}
+
+type VectorResponse struct {
+	Result               *Vector  `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VectorResponse) Reset()         { *m = VectorResponse{} }
+func (m *VectorResponse) String() string { return proto.CompactTextString(m) }
+func (*VectorResponse) ProtoMessage()    {}
+func (*VectorResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{43}
+}
+
+func (m *VectorResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VectorResponse.Unmarshal(m, b)
+}
+func (m *VectorResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VectorResponse.Marshal(b, m, deterministic)
+}
+func (m *VectorResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VectorResponse.Merge(m, src)
+}
+func (m *VectorResponse) XXX_Size() int {
+	return xxx_messageInfo_VectorResponse.Size(m)
+}
+func (m *VectorResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VectorResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VectorResponse proto.InternalMessageInfo
+
+func (m *VectorResponse) GetResult() *Vector {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type ScalarResponse struct {
+	Result               float64  `protobuf:"fixed64,1,opt,name=result,proto3" json:"result,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScalarResponse) Reset()         { *m = ScalarResponse{} }
+func (m *ScalarResponse) String() string { return proto.CompactTextString(m) }
+func (*ScalarResponse) ProtoMessage()    {}
+func (*ScalarResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{44}
+}
+
+func (m *ScalarResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScalarResponse.Unmarshal(m, b)
+}
+func (m *ScalarResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScalarResponse.Marshal(b, m, deterministic)
+}
+func (m *ScalarResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScalarResponse.Merge(m, src)
+}
+func (m *ScalarResponse) XXX_Size() int {
+	return xxx_messageInfo_ScalarResponse.Size(m)
+}
+func (m *ScalarResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScalarResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScalarResponse proto.InternalMessageInfo
+
+func (m *ScalarResponse) GetResult() float64 {
+	if m != nil {
+		return m.Result
+	}
+	return 0
+}
+
+type MatrixVectorMultiplyRequest struct {
+	Matrix               *Matrix  `protobuf:"bytes,1,opt,name=matrix,proto3" json:"matrix,omitempty"`
+	Vector               *Vector  `protobuf:"bytes,2,opt,name=vector,proto3" json:"vector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MatrixVectorMultiplyRequest) Reset()         { *m = MatrixVectorMultiplyRequest{} }
+func (m *MatrixVectorMultiplyRequest) String() string { return proto.CompactTextString(m) }
+func (*MatrixVectorMultiplyRequest) ProtoMessage()    {}
+func (*MatrixVectorMultiplyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{45}
+}
+
+func (m *MatrixVectorMultiplyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MatrixVectorMultiplyRequest.Unmarshal(m, b)
+}
+func (m *MatrixVectorMultiplyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MatrixVectorMultiplyRequest.Marshal(b, m, deterministic)
+}
+func (m *MatrixVectorMultiplyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatrixVectorMultiplyRequest.Merge(m, src)
+}
+func (m *MatrixVectorMultiplyRequest) XXX_Size() int {
+	return xxx_messageInfo_MatrixVectorMultiplyRequest.Size(m)
+}
+func (m *MatrixVectorMultiplyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatrixVectorMultiplyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatrixVectorMultiplyRequest proto.InternalMessageInfo
+
+func (m *MatrixVectorMultiplyRequest) GetMatrix() *Matrix {
+	if m != nil {
+		return m.Matrix
+	}
+	return nil
+}
+
+func (m *MatrixVectorMultiplyRequest) GetVector() *Vector {
+	if m != nil {
+		return m.Vector
+	}
+	return nil
+}
+
+// ConvertRequest converts value between units given as symbols such as
+// km, lb, degC or MiB, or as products and quotients of them such as km/h
+// or kg*m/s^2
+type ConvertRequest struct {
+	Value                float64  `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	From                 string   `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                   string   `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConvertRequest) Reset()         { *m = ConvertRequest{} }
+func (m *ConvertRequest) String() string { return proto.CompactTextString(m) }
+func (*ConvertRequest) ProtoMessage()    {}
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{46}
+}
+
+func (m *ConvertRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConvertRequest.Unmarshal(m, b)
+}
+func (m *ConvertRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConvertRequest.Marshal(b, m, deterministic)
+}
+func (m *ConvertRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConvertRequest.Merge(m, src)
+}
+func (m *ConvertRequest) XXX_Size() int {
+	return xxx_messageInfo_ConvertRequest.Size(m)
+}
+func (m *ConvertRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConvertRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConvertRequest proto.InternalMessageInfo
+
+func (m *ConvertRequest) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *ConvertRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ConvertRequest) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+type ConvertResponse struct {
+	Value                float64  `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConvertResponse) Reset()         { *m = ConvertResponse{} }
+func (m *ConvertResponse) String() string { return proto.CompactTextString(m) }
+func (*ConvertResponse) ProtoMessage()    {}
+func (*ConvertResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c3514e02e3bedff2, []int{47}
+}
+
+func (m *ConvertResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConvertResponse.Unmarshal(m, b)
+}
+func (m *ConvertResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConvertResponse.Marshal(b, m, deterministic)
+}
+func (m *ConvertResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConvertResponse.Merge(m, src)
+}
+func (m *ConvertResponse) XXX_Size() int {
+	return xxx_messageInfo_ConvertResponse.Size(m)
+}
+func (m *ConvertResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConvertResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConvertResponse proto.InternalMessageInfo
+
+func (m *ConvertResponse) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("calculator.Aggregate", Aggregate_name, Aggregate_value)
+	proto.RegisterType((*SumRequest)(nil), "calculator.SumRequest")
+	proto.RegisterType((*SumResponse)(nil), "calculator.SumResponse")
+	proto.RegisterType((*PrimeNumberDecompositionRequest)(nil), "calculator.PrimeNumberDecompositionRequest")
+	proto.RegisterType((*PrimeNumberDecompositionResponse)(nil), "calculator.PrimeNumberDecompositionResponse")
+	proto.RegisterType((*ComputeAverageRequest)(nil), "calculator.ComputeAverageRequest")
+	proto.RegisterType((*ComputeAverageResponse)(nil), "calculator.ComputeAverageResponse")
+	proto.RegisterType((*FindMaximumRequest)(nil), "calculator.FindMaximumRequest")
+	proto.RegisterType((*FindMaximumResponse)(nil), "calculator.FindMaximumResponse")
+	proto.RegisterType((*SquareRootRequest)(nil), "calculator.SquareRootRequest")
+	proto.RegisterType((*SquareRootResponse)(nil), "calculator.SquareRootResponse")
+	proto.RegisterType((*BigSumRequest)(nil), "calculator.BigSumRequest")
+	proto.RegisterType((*BigSumResponse)(nil), "calculator.BigSumResponse")
+	proto.RegisterType((*BigComputeAverageRequest)(nil), "calculator.BigComputeAverageRequest")
+	proto.RegisterType((*BigComputeAverageResponse)(nil), "calculator.BigComputeAverageResponse")
+	proto.RegisterType((*Number)(nil), "calculator.Number")
+	proto.RegisterType((*BinaryOperationRequest)(nil), "calculator.BinaryOperationRequest")
+	proto.RegisterType((*NumberResponse)(nil), "calculator.NumberResponse")
+	proto.RegisterType((*FactorialRequest)(nil), "calculator.FactorialRequest")
+	proto.RegisterType((*FactorialResponse)(nil), "calculator.FactorialResponse")
+	proto.RegisterType((*EvaluateRequest)(nil), "calculator.EvaluateRequest")
+	proto.RegisterMapType((map[string]float64)(nil), "calculator.EvaluateRequest.VariablesEntry")
+	proto.RegisterType((*EvaluateResponse)(nil), "calculator.EvaluateResponse")
+	proto.RegisterType((*IsPrimeRequest)(nil), "calculator.IsPrimeRequest")
+	proto.RegisterType((*IsPrimeResponse)(nil), "calculator.IsPrimeResponse")
+	proto.RegisterType((*NextPrimeRequest)(nil), "calculator.NextPrimeRequest")
+	proto.RegisterType((*NextPrimeResponse)(nil), "calculator.NextPrimeResponse")
+	proto.RegisterType((*PrimesInRangeRequest)(nil), "calculator.PrimesInRangeRequest")
+	proto.RegisterType((*PrimesInRangeResponse)(nil), "calculator.PrimesInRangeResponse")
+	proto.RegisterType((*ComputeStatisticsRequest)(nil), "calculator.ComputeStatisticsRequest")
+	proto.RegisterType((*Percentile)(nil), "calculator.Percentile")
+	proto.RegisterType((*ComputeStatisticsResponse)(nil), "calculator.ComputeStatisticsResponse")
+	proto.RegisterType((*Window)(nil), "calculator.Window")
+	proto.RegisterType((*RunningAggregateRequest)(nil), "calculator.RunningAggregateRequest")
+	proto.RegisterType((*RunningAggregateResponse)(nil), "calculator.RunningAggregateResponse")
+	proto.RegisterType((*Matrix)(nil), "calculator.Matrix")
+	proto.RegisterType((*MatrixRequest)(nil), "calculator.MatrixRequest")
+	proto.RegisterType((*MatrixPairRequest)(nil), "calculator.MatrixPairRequest")
+	proto.RegisterType((*MatrixResponse)(nil), "calculator.MatrixResponse")
+	proto.RegisterType((*DeterminantResponse)(nil), "calculator.DeterminantResponse")
+	proto.RegisterType((*SolveLinearSystemRequest)(nil), "calculator.SolveLinearSystemRequest")
+	proto.RegisterType((*SolveLinearSystemResponse)(nil), "calculator.SolveLinearSystemResponse")
+	proto.RegisterType((*Vector)(nil), "calculator.Vector")
+	proto.RegisterType((*VectorRequest)(nil), "calculator.VectorRequest")
+	proto.RegisterType((*VectorPairRequest)(nil), "calculator.VectorPairRequest")
+	proto.RegisterType((*VectorResponse)(nil), "calculator.VectorResponse")
+	proto.RegisterType((*ScalarResponse)(nil), "calculator.ScalarResponse")
+	proto.RegisterType((*MatrixVectorMultiplyRequest)(nil), "calculator.MatrixVectorMultiplyRequest")
+	proto.RegisterType((*ConvertRequest)(nil), "calculator.ConvertRequest")
+	proto.RegisterType((*ConvertResponse)(nil), "calculator.ConvertResponse")
+}
+
+func init() {
+	proto.RegisterFile("calculator-app/calculatorpb/calculator.proto", fileDescriptor_c3514e02e3bedff2)
+}
+
+var fileDescriptor_c3514e02e3bedff2 = []byte{
+	// 1869 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0xef, 0x52, 0xe4, 0xc6,
+	0x11, 0x47, 0xbb, 0xb0, 0xb0, 0xbd, 0xb0, 0xc7, 0x8e, 0x39, 0x2c, 0x74, 0xc7, 0x19, 0x64, 0x5f,
+	0x99, 0x60, 0x7c, 0xe7, 0xc2, 0x89, 0xed, 0x38, 0xae, 0xa4, 0x58, 0x58, 0x58, 0x6c, 0x43, 0x28,
+	0x2d, 0x3e, 0xa7, 0xe2, 0x4a, 0x11, 0x21, 0x0d, 0x5b, 0x53, 0x91, 0x34, 0x6b, 0x69, 0xb4, 0x40,
+	0xde, 0x21, 0x5f, 0xf2, 0x29, 0xaf, 0x92, 0xb7, 0x4b, 0x69, 0x66, 0x24, 0x8d, 0x76, 0xb5, 0x7f,
+	0x72, 0x77, 0xdf, 0x76, 0x7a, 0x7e, 0xfd, 0x9b, 0x56, 0x77, 0x4f, 0x4f, 0x37, 0xc0, 0x81, 0x63,
+	0x7b, 0x4e, 0xec, 0xd9, 0x8c, 0x86, 0x9f, 0xdb, 0x83, 0xc1, 0xeb, 0x7c, 0x39, 0xb8, 0x55, 0x16,
+	0xaf, 0x06, 0x21, 0x65, 0x14, 0x41, 0x2e, 0x31, 0xaf, 0x01, 0x7a, 0xb1, 0x6f, 0xe1, 0x5f, 0x63,
+	0x1c, 0x31, 0xb4, 0x0b, 0xab, 0x77, 0x24, 0x8c, 0xd8, 0x4d, 0x10, 0xfb, 0xb7, 0x38, 0xd4, 0xb5,
+	0x1d, 0x6d, 0x6f, 0xc9, 0x6a, 0x70, 0xd9, 0x25, 0x17, 0xa1, 0x8f, 0x61, 0x2d, 0xc2, 0x0e, 0x0d,
+	0xdc, 0x14, 0x53, 0xe1, 0x98, 0x55, 0x21, 0x14, 0x20, 0xf3, 0x00, 0x1a, 0x9c, 0x35, 0x1a, 0xd0,
+	0x20, 0xc2, 0x68, 0x1b, 0x20, 0x8a, 0xfd, 0x9b, 0x10, 0x47, 0xb1, 0xc7, 0x24, 0x69, 0x3d, 0xe2,
+	0x80, 0xd8, 0x63, 0xe6, 0xef, 0xe1, 0xa3, 0xab, 0x90, 0xf8, 0x58, 0x28, 0x9f, 0x60, 0x87, 0xfa,
+	0x03, 0x1a, 0x11, 0x46, 0x68, 0x90, 0x1a, 0xb6, 0x09, 0x35, 0xc5, 0xa4, 0xaa, 0x25, 0x57, 0x66,
+	0x07, 0x76, 0x26, 0xab, 0xca, 0xd3, 0x77, 0x61, 0x75, 0x90, 0x60, 0x6e, 0xee, 0x6c, 0x87, 0xd1,
+	0x94, 0xa1, 0xc1, 0x65, 0xa7, 0x5c, 0x64, 0xbe, 0x86, 0xa7, 0xc7, 0xd4, 0x1f, 0xc4, 0x0c, 0x1f,
+	0x0d, 0x71, 0x68, 0xf7, 0x71, 0xf9, 0xb9, 0x4b, 0xd9, 0xb9, 0x87, 0xb0, 0x39, 0xaa, 0x20, 0x4f,
+	0xd3, 0x61, 0xd9, 0x16, 0x22, 0xae, 0xa2, 0x59, 0xe9, 0xd2, 0x3c, 0x00, 0x74, 0x4a, 0x02, 0xf7,
+	0xc2, 0x7e, 0x20, 0x7e, 0xec, 0xcf, 0x3a, 0xe1, 0x35, 0x7c, 0x50, 0x40, 0xe7, 0xf4, 0xbe, 0x10,
+	0x49, 0x7c, 0xba, 0x34, 0xff, 0x06, 0xad, 0xde, 0xaf, 0xb1, 0x1d, 0x62, 0x8b, 0x52, 0x96, 0xb2,
+	0xeb, 0x45, 0xf6, 0xee, 0x42, 0xca, 0x8f, 0x5e, 0xc2, 0x9a, 0x4b, 0xe3, 0x5b, 0x0f, 0xab, 0x71,
+	0xd4, 0xba, 0x0b, 0xd6, 0xaa, 0x10, 0x0b, 0x8f, 0xb6, 0x97, 0x61, 0x89, 0x04, 0x83, 0x98, 0x99,
+	0xbf, 0x05, 0xa4, 0xd2, 0x4b, 0x73, 0x5e, 0x00, 0x08, 0xf5, 0x44, 0x2a, 0x3f, 0x58, 0x91, 0x98,
+	0x3f, 0xc3, 0x5a, 0x9b, 0xf4, 0x67, 0x64, 0x58, 0x7d, 0x8e, 0x0c, 0xab, 0x8f, 0x64, 0xd8, 0x6b,
+	0x68, 0xa6, 0xc4, 0x13, 0x93, 0xac, 0xae, 0x26, 0xd9, 0x21, 0xe8, 0x6d, 0xd2, 0x9f, 0x27, 0xca,
+	0xf5, 0x2c, 0x06, 0xbf, 0x83, 0xad, 0x12, 0x9d, 0xf2, 0x40, 0xd7, 0xf3, 0x40, 0xdf, 0x40, 0x4d,
+	0x7e, 0x8a, 0x01, 0xcb, 0x24, 0x60, 0xb8, 0x9f, 0xe6, 0x6d, 0x77, 0xc1, 0x4a, 0x05, 0x68, 0x03,
+	0x16, 0x43, 0x6c, 0x7b, 0x99, 0xdf, 0xf9, 0x2a, 0xd1, 0x70, 0xb1, 0x43, 0x7c, 0xdb, 0xd3, 0xab,
+	0x09, 0x6b, 0xa2, 0x21, 0x05, 0x49, 0x2c, 0x86, 0xb6, 0x17, 0x63, 0x33, 0x80, 0xcd, 0x36, 0x09,
+	0xec, 0xf0, 0xf1, 0xcf, 0x03, 0x1c, 0xda, 0xea, 0x3d, 0xd9, 0x83, 0x25, 0xee, 0x4a, 0x7e, 0x5c,
+	0xe3, 0x10, 0xbd, 0x52, 0x2e, 0xbf, 0xb0, 0xc9, 0x12, 0x00, 0xb4, 0x0f, 0x35, 0xe1, 0x50, 0xbd,
+	0x32, 0x11, 0x2a, 0x11, 0xe6, 0x77, 0xd0, 0x94, 0x92, 0xf4, 0xe3, 0xf7, 0xa1, 0xa6, 0x38, 0x7a,
+	0x82, 0xb6, 0x40, 0x98, 0x7f, 0x84, 0x75, 0x71, 0xcd, 0x88, 0xed, 0xa5, 0x76, 0xee, 0x17, 0x3c,
+	0x3e, 0x41, 0x5f, 0x46, 0xe1, 0x33, 0x68, 0x29, 0xfa, 0xd2, 0x80, 0xcd, 0x82, 0x01, 0xf5, 0xec,
+	0xb0, 0xff, 0x6a, 0xf0, 0xa4, 0x93, 0x78, 0xc9, 0x66, 0x59, 0x78, 0x5f, 0x00, 0xe0, 0x87, 0x41,
+	0x88, 0xa3, 0x88, 0xd0, 0x40, 0xe2, 0x15, 0x09, 0xea, 0x42, 0x7d, 0x68, 0x87, 0xc4, 0xbe, 0xf5,
+	0x70, 0xa4, 0x57, 0x76, 0xaa, 0x7b, 0x8d, 0xc3, 0x7d, 0xd5, 0x9e, 0x11, 0xbe, 0x57, 0x6f, 0x52,
+	0x70, 0x27, 0x60, 0xe1, 0xa3, 0x95, 0x2b, 0x1b, 0xdf, 0x41, 0xb3, 0xb8, 0x89, 0xd6, 0xa1, 0xfa,
+	0x0f, 0xfc, 0x28, 0x0f, 0x4d, 0x7e, 0xa2, 0x0d, 0x19, 0x45, 0x11, 0x78, 0x4b, 0x2c, 0xbe, 0xad,
+	0x7c, 0xa3, 0x99, 0xfb, 0xb0, 0x9e, 0x1f, 0x55, 0xfa, 0x9d, 0x5a, 0xf6, 0x9d, 0x7b, 0xd0, 0x3c,
+	0x8f, 0x78, 0xe9, 0x9b, 0x55, 0x22, 0x0f, 0xe0, 0x49, 0x86, 0x94, 0xa4, 0x5b, 0xb0, 0x42, 0xa2,
+	0x1b, 0x5e, 0x00, 0x39, 0x78, 0xc5, 0x5a, 0x26, 0x02, 0x92, 0xd8, 0x70, 0x89, 0x1f, 0xd8, 0x5c,
+	0xcc, 0xbf, 0x81, 0x96, 0x82, 0x95, 0xdc, 0x1b, 0xb0, 0x94, 0x13, 0x57, 0x2d, 0xb1, 0x30, 0xbf,
+	0x85, 0x0d, 0x0e, 0x8b, 0xce, 0x03, 0xcb, 0x0e, 0xf2, 0x9b, 0x87, 0x60, 0xf1, 0x2e, 0xa4, 0xbe,
+	0x04, 0xf3, 0xdf, 0xa8, 0x09, 0x15, 0x46, 0xb9, 0x77, 0xaa, 0x56, 0x85, 0xd1, 0xa4, 0x38, 0x8f,
+	0xe8, 0xe6, 0xbe, 0xe1, 0xec, 0x91, 0xae, 0xed, 0x54, 0x13, 0xbb, 0xc4, 0xca, 0xbc, 0x06, 0x5d,
+	0xde, 0xd9, 0x1e, 0xb3, 0x19, 0x89, 0x18, 0x71, 0xa2, 0xf2, 0x6f, 0xd1, 0xb2, 0x72, 0xb8, 0x03,
+	0x8d, 0x01, 0x0e, 0x1d, 0x1c, 0x30, 0x92, 0x66, 0x81, 0x66, 0xa9, 0x22, 0xb3, 0x0d, 0x70, 0x95,
+	0x2d, 0x93, 0x9c, 0xca, 0x37, 0xd3, 0xc2, 0x97, 0x4b, 0xca, 0xa3, 0x6c, 0xfe, 0xa7, 0x02, 0x5b,
+	0x25, 0xa6, 0xe5, 0xae, 0x73, 0x68, 0x1c, 0xb0, 0xd4, 0x75, 0x7c, 0x91, 0x64, 0x50, 0x14, 0xfb,
+	0x92, 0x27, 0xf9, 0x99, 0x38, 0xcd, 0xc7, 0x76, 0xc0, 0x0b, 0x84, 0x66, 0xf1, 0xdf, 0x09, 0xca,
+	0x27, 0x81, 0xbe, 0x28, 0x50, 0x3e, 0x11, 0x12, 0xfb, 0x41, 0x5f, 0x92, 0x12, 0xfb, 0x01, 0x19,
+	0xb0, 0xc2, 0x53, 0x35, 0x70, 0xb0, 0x5e, 0xe3, 0xe2, 0x6c, 0x8d, 0x3e, 0x07, 0x14, 0x31, 0x3b,
+	0x70, 0xed, 0xd0, 0xbd, 0x71, 0xf1, 0x90, 0xf0, 0xaa, 0xa2, 0x2f, 0x73, 0x54, 0x2b, 0xdd, 0x39,
+	0x49, 0x37, 0x12, 0x37, 0xfa, 0xd8, 0x25, 0x76, 0xa0, 0xaf, 0x08, 0x37, 0x8a, 0x15, 0xfa, 0xa6,
+	0xe8, 0xc6, 0x3a, 0xbf, 0x4c, 0x9b, 0xea, 0x65, 0xca, 0x7d, 0x58, 0x74, 0x6f, 0x1f, 0x6a, 0x3f,
+	0x93, 0xc0, 0xa5, 0xf7, 0x68, 0x53, 0x75, 0xc3, 0x5a, 0x77, 0x21, 0x75, 0xc4, 0x2e, 0x34, 0xdc,
+	0x58, 0x94, 0xbb, 0x1b, 0x3f, 0x12, 0x09, 0xd2, 0x5d, 0xb0, 0x20, 0x15, 0x5e, 0x44, 0xc9, 0x17,
+	0xb2, 0xd8, 0xbf, 0xf5, 0x48, 0xd0, 0xe7, 0xde, 0x59, 0xb1, 0xb2, 0x75, 0xbb, 0x06, 0x8b, 0x11,
+	0xf9, 0x27, 0x36, 0xff, 0xad, 0xc1, 0x87, 0x56, 0x1c, 0x04, 0x24, 0xe8, 0x1f, 0xf5, 0xfb, 0x21,
+	0xee, 0x2b, 0x95, 0xe2, 0x4b, 0xa8, 0xdb, 0xa9, 0x8c, 0x1f, 0xdf, 0x3c, 0x7c, 0xaa, 0x1a, 0x9f,
+	0x2b, 0xe4, 0xb8, 0xa4, 0x96, 0xdd, 0x73, 0xcb, 0xcb, 0x2a, 0xa9, 0xf8, 0x26, 0x4b, 0x22, 0x94,
+	0xf4, 0xab, 0xaa, 0xe9, 0x67, 0x9e, 0x82, 0x3e, 0x6e, 0x53, 0x9e, 0x16, 0x22, 0x95, 0x34, 0x25,
+	0x95, 0xf2, 0x64, 0xa9, 0x28, 0xc9, 0x62, 0x76, 0xa1, 0x76, 0x61, 0xb3, 0x90, 0x3c, 0x24, 0x49,
+	0x12, 0xd2, 0xfb, 0x48, 0x76, 0x09, 0xfc, 0x77, 0x22, 0x73, 0xa8, 0x17, 0xc9, 0x96, 0x8d, 0xff,
+	0x4e, 0x2c, 0xe2, 0x84, 0x91, 0x5e, 0xe5, 0x39, 0x2f, 0x57, 0xe6, 0x1f, 0x60, 0x4d, 0x30, 0x29,
+	0x25, 0xdb, 0xe7, 0x82, 0xb2, 0x92, 0x2d, 0xa1, 0x12, 0x61, 0x12, 0x68, 0x09, 0xc9, 0x95, 0x4d,
+	0xc2, 0x79, 0xde, 0x26, 0xa9, 0x3f, 0xcf, 0xdb, 0x94, 0x1e, 0x95, 0xbf, 0x4d, 0xa9, 0x9d, 0xf3,
+	0xbc, 0x4d, 0xa9, 0xb6, 0x2c, 0xa3, 0x5f, 0xc3, 0x07, 0x27, 0x98, 0xe1, 0xd0, 0x27, 0x81, 0x1d,
+	0xe4, 0x6d, 0xcd, 0x0e, 0x34, 0xdc, 0x5c, 0x2c, 0x1d, 0xaf, 0x8a, 0xcc, 0x01, 0xe8, 0x3d, 0xea,
+	0x0d, 0xf1, 0x8f, 0x24, 0xc0, 0x76, 0xd8, 0x7b, 0x8c, 0x18, 0xce, 0x7a, 0x9c, 0xaf, 0x60, 0xd5,
+	0xa1, 0xf8, 0xee, 0x8e, 0x38, 0x04, 0x07, 0x2c, 0x9a, 0x62, 0x46, 0x01, 0x87, 0x9e, 0x43, 0xdd,
+	0xa1, 0x41, 0x72, 0xd9, 0x58, 0x5a, 0x81, 0x72, 0x81, 0xf9, 0x35, 0x6c, 0x95, 0x9c, 0x28, 0x0d,
+	0x36, 0x60, 0x25, 0xa2, 0x5e, 0xcc, 0xc4, 0x03, 0x97, 0x68, 0x66, 0x6b, 0x73, 0x07, 0x6a, 0x6f,
+	0x70, 0xf2, 0x7e, 0x2a, 0xb1, 0xd6, 0x46, 0x63, 0x2d, 0x10, 0x4a, 0xac, 0x87, 0x38, 0x6b, 0x96,
+	0x47, 0x6c, 0x97, 0x50, 0x89, 0x48, 0x62, 0x2d, 0x24, 0xf3, 0xc6, 0x5a, 0xea, 0xcf, 0x13, 0xeb,
+	0xf4, 0xa8, 0x3c, 0xd6, 0xa9, 0x9d, 0xf3, 0xc4, 0x3a, 0xd5, 0xce, 0x9f, 0xcc, 0x9e, 0x63, 0x7b,
+	0x76, 0x38, 0xf3, 0x71, 0x8d, 0xe1, 0x99, 0x08, 0x90, 0x60, 0xb8, 0x88, 0x3d, 0x46, 0x06, 0xde,
+	0xe3, 0x5b, 0xdc, 0x04, 0xc5, 0x93, 0x95, 0x99, 0x9e, 0xfc, 0x1e, 0x9a, 0xc7, 0x34, 0x18, 0xe2,
+	0x30, 0x6b, 0xdf, 0xcb, 0xaf, 0x7e, 0xfa, 0x68, 0x8a, 0xbe, 0x58, 0x7d, 0x34, 0x79, 0xcb, 0xc8,
+	0x1f, 0xcd, 0x4f, 0xe1, 0x49, 0xc6, 0x35, 0xad, 0x8e, 0xec, 0x47, 0x50, 0xcf, 0x4a, 0x0e, 0xda,
+	0x82, 0xa7, 0x47, 0x67, 0x67, 0x56, 0xe7, 0xec, 0xe8, 0xba, 0x73, 0xf3, 0xd3, 0x65, 0xef, 0xaa,
+	0x73, 0x7c, 0x7e, 0x7a, 0xde, 0x39, 0x59, 0x5f, 0x40, 0x2d, 0x58, 0xcb, 0xb7, 0x2e, 0x8e, 0xfe,
+	0xb2, 0xae, 0x8d, 0x88, 0xce, 0x2f, 0xd7, 0x2b, 0x08, 0x41, 0x53, 0x11, 0x75, 0x8e, 0x2e, 0xd7,
+	0xab, 0x45, 0x58, 0xef, 0xa7, 0x8b, 0xf5, 0xc5, 0xc3, 0x7f, 0x3d, 0x85, 0xd6, 0x71, 0xe6, 0x87,
+	0x1e, 0x0e, 0x87, 0xc4, 0xc1, 0xe8, 0x2b, 0xa8, 0xf6, 0x62, 0x1f, 0x15, 0x9e, 0x8b, 0x7c, 0x74,
+	0x30, 0x3e, 0x1c, 0x93, 0xcb, 0x0f, 0xbb, 0x07, 0x7d, 0xd2, 0x10, 0x88, 0x3e, 0x2b, 0xbc, 0x3d,
+	0xd3, 0xa7, 0x4c, 0xe3, 0x60, 0x3e, 0xb0, 0x38, 0xf6, 0x0b, 0x0d, 0xfd, 0x02, 0x4d, 0xf9, 0x9a,
+	0xcb, 0xe1, 0x00, 0xed, 0xaa, 0x0c, 0xa5, 0xc3, 0x86, 0x61, 0x4e, 0x83, 0x08, 0x6a, 0x73, 0x61,
+	0x4f, 0x43, 0xd7, 0xd0, 0x50, 0x06, 0x40, 0xf4, 0x42, 0x55, 0x1b, 0x9f, 0x23, 0x8d, 0x8f, 0x26,
+	0xee, 0xe7, 0x9c, 0x5f, 0x68, 0xe8, 0x07, 0x80, 0x7c, 0x8c, 0x43, 0xdb, 0x05, 0x97, 0x8e, 0x4e,
+	0x8f, 0xc6, 0x8b, 0x49, 0xdb, 0xd2, 0xf1, 0x7f, 0x82, 0x9a, 0x18, 0xc2, 0xd0, 0x96, 0x8a, 0x2c,
+	0x4c, 0x7c, 0x86, 0x51, 0xb6, 0x25, 0x09, 0x5c, 0x68, 0x8d, 0x0d, 0x58, 0xe8, 0x93, 0x11, 0x85,
+	0x72, 0x37, 0xbe, 0x9c, 0x81, 0x52, 0x3c, 0xd9, 0x81, 0xea, 0x91, 0xeb, 0x22, 0xb3, 0xa8, 0x51,
+	0x36, 0x3f, 0x15, 0x8d, 0x1d, 0x99, 0x79, 0xbe, 0x87, 0x95, 0x5e, 0x7c, 0xcb, 0x42, 0xdb, 0x61,
+	0xef, 0x83, 0x2b, 0xad, 0x2a, 0xef, 0xcc, 0xd5, 0x85, 0xda, 0x09, 0x19, 0x12, 0x17, 0xbf, 0x0f,
+	0xa6, 0x0b, 0xea, 0xc6, 0x1e, 0x7d, 0x67, 0xa6, 0x33, 0x58, 0xba, 0xa2, 0xf7, 0x38, 0x7c, 0x67,
+	0xa2, 0x0e, 0x54, 0xcf, 0x8e, 0x4f, 0xde, 0x07, 0xcd, 0x8f, 0xc7, 0x17, 0xef, 0xc1, 0x41, 0xf5,
+	0x6c, 0x14, 0x45, 0xcf, 0x0b, 0x37, 0x6e, 0x64, 0xc2, 0x35, 0xb6, 0x27, 0xec, 0x66, 0x06, 0xad,
+	0xa4, 0xb3, 0x1e, 0x7a, 0x36, 0x65, 0xd8, 0x34, 0x9e, 0x97, 0x6f, 0x4a, 0x9a, 0x36, 0x2c, 0xcb,
+	0xe1, 0x0e, 0x15, 0xec, 0x2e, 0xce, 0x86, 0xc6, 0xb3, 0xd2, 0xbd, 0xfc, 0xa3, 0xb2, 0x31, 0xae,
+	0xf8, 0x51, 0xa3, 0x93, 0xa0, 0xb1, 0x3d, 0x61, 0x57, 0x32, 0xbd, 0x81, 0xb5, 0xc2, 0xa4, 0x86,
+	0x76, 0xc6, 0x0a, 0xea, 0xc8, 0x00, 0x68, 0xec, 0x4e, 0x41, 0x64, 0x75, 0xd6, 0x85, 0xd6, 0xd8,
+	0xd4, 0x54, 0x2c, 0x13, 0x93, 0xe6, 0x3d, 0xe3, 0xe5, 0x0c, 0x94, 0x52, 0x26, 0x1c, 0x58, 0x1f,
+	0xed, 0xc1, 0xd1, 0xc7, 0xaa, 0xfa, 0x84, 0xa9, 0xc1, 0xf8, 0x64, 0x3a, 0xa8, 0x50, 0x7f, 0x4f,
+	0xa1, 0x2e, 0x3a, 0x84, 0xa4, 0x22, 0x6d, 0x8f, 0x37, 0x0e, 0x4a, 0x13, 0x65, 0x18, 0xe3, 0xdb,
+	0x99, 0xab, 0x7f, 0x48, 0xdb, 0xde, 0xac, 0x8c, 0xbc, 0x03, 0x59, 0x17, 0x9e, 0x08, 0xc9, 0x75,
+	0x68, 0x07, 0xd1, 0x80, 0x26, 0x7f, 0x22, 0x28, 0x83, 0xcf, 0x66, 0x3a, 0x87, 0x86, 0xd2, 0x4f,
+	0x4f, 0x63, 0x29, 0xbc, 0x57, 0x65, 0x3d, 0xf8, 0x69, 0x3a, 0x80, 0x9c, 0x27, 0x6d, 0xcc, 0xdb,
+	0x9b, 0xf4, 0x77, 0x68, 0x8d, 0xf5, 0xcd, 0xc5, 0xe4, 0x99, 0xd4, 0xc8, 0x1b, 0x2f, 0x67, 0xa0,
+	0x32, 0x4b, 0xeb, 0xa2, 0x93, 0x1b, 0x8b, 0xe9, 0x58, 0x63, 0x6c, 0x18, 0xe3, 0xdb, 0x4a, 0xd1,
+	0x84, 0x13, 0xca, 0xae, 0x42, 0xea, 0xc6, 0x0e, 0xfb, 0xbf, 0x88, 0x46, 0xfa, 0xda, 0x73, 0x58,
+	0x3d, 0x0e, 0x69, 0x14, 0xbd, 0x0d, 0xd5, 0x88, 0x4d, 0xc7, 0x00, 0x42, 0x72, 0x49, 0xc3, 0x91,
+	0x67, 0xbe, 0x30, 0x32, 0x4c, 0xb5, 0xe7, 0x17, 0xd8, 0x28, 0xeb, 0xa7, 0xd1, 0xa7, 0xe3, 0x61,
+	0x2b, 0xed, 0xb8, 0xa7, 0x5a, 0xd8, 0x86, 0x65, 0xd9, 0xe9, 0x16, 0x4b, 0x60, 0xb1, 0x95, 0x36,
+	0x9e, 0x95, 0xee, 0x09, 0x8e, 0x76, 0xf3, 0xaf, 0xab, 0xea, 0xbf, 0x4c, 0x6e, 0x6b, 0xfc, 0x1f,
+	0x25, 0x5f, 0xfe, 0x6f, 0x00, 0x3b, 0x6a, 0x42, 0xac, 0x58, 0x19, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// CalculatorServiceClient is the client API for CalculatorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CalculatorServiceClient interface {
+	// unary api, fails with OUT_OF_RANGE when the sum does not fit in int32
+	Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error)
+	// Stream of the prime factors in ascending order, each repeated as often
+	// as it divides number. A negative number starts with -1 so the factors
+	// multiply to it, 1 has no factors and 0 fails with INVALID_ARGUMENT.
+	PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error)
+	// Client Stream, INVALID_ARGUMENT when no numbers are sent;
+	// superseded by ComputeStatistics
+	ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error)
+	// BI Stream, reports every new maximum starting with the first number
+	FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error)
+	SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error)
+	// arbitrary-precision variants of Sum and ComputeAverage
+	BigSum(ctx context.Context, in *BigSumRequest, opts ...grpc.CallOption) (*BigSumResponse, error)
+	BigComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_BigComputeAverageClient, error)
+	// arithmetic on Numbers: INVALID_ARGUMENT for a missing or non-finite
+	// operand or a zero divisor, OUT_OF_RANGE when the result overflows.
+	// Integer results beyond int64 are available as decimals by sending
+	// decimal operands
+	Add(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error)
+	Subtract(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error)
+	Multiply(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error)
+	// integer when the quotient is exact, real otherwise
+	Divide(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error)
+	// the result has the sign of the first operand
+	Modulo(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error)
+	// decimal powers take an integer exponent
+	Power(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error)
+	// GCD, LCM and Factorial take integers, or reals with no fractional part
+	GCD(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error)
+	LCM(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error)
+	Factorial(ctx context.Context, in *FactorialRequest, opts ...grpc.CallOption) (*FactorialResponse, error)
+	// INVALID_ARGUMENT for expressions that do not parse or evaluate, with the
+	// 1-based column of the problem in the error details
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	// deterministic for every int64, negative numbers are not prime
+	IsPrime(ctx context.Context, in *IsPrimeRequest, opts ...grpc.CallOption) (*IsPrimeResponse, error)
+	// the smallest prime greater than number, OUT_OF_RANGE past the
+	// largest int64 prime
+	NextPrime(ctx context.Context, in *NextPrimeRequest, opts ...grpc.CallOption) (*NextPrimeResponse, error)
+	// INVALID_ARGUMENT when the range is reversed or larger than the server allows
+	PrimesInRange(ctx context.Context, in *PrimesInRangeRequest, opts ...grpc.CallOption) (CalculatorService_PrimesInRangeClient, error)
+	// INVALID_ARGUMENT when no numbers are sent
+	ComputeStatistics(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeStatisticsClient, error)
+	RunningAggregate(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_RunningAggregateClient, error)
+	// linear algebra: INVALID_ARGUMENT when the dimensions do not fit the
+	// operation, OUT_OF_RANGE when a result overflows
+	MatrixAdd(ctx context.Context, in *MatrixPairRequest, opts ...grpc.CallOption) (*MatrixResponse, error)
+	MatrixMultiply(ctx context.Context, in *MatrixPairRequest, opts ...grpc.CallOption) (*MatrixResponse, error)
+	MatrixTranspose(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*MatrixResponse, error)
+	Determinant(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*DeterminantResponse, error)
+	// INVALID_ARGUMENT for a singular matrix
+	MatrixInverse(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*MatrixResponse, error)
+	// INVALID_ARGUMENT when the system has no unique solution
+	SolveLinearSystem(ctx context.Context, in *SolveLinearSystemRequest, opts ...grpc.CallOption) (*SolveLinearSystemResponse, error)
+	VectorAdd(ctx context.Context, in *VectorPairRequest, opts ...grpc.CallOption) (*VectorResponse, error)
+	DotProduct(ctx context.Context, in *VectorPairRequest, opts ...grpc.CallOption) (*ScalarResponse, error)
+	// only defined for vectors of three values
+	CrossProduct(ctx context.Context, in *VectorPairRequest, opts ...grpc.CallOption) (*VectorResponse, error)
+	// the Euclidean length of the vector
+	VectorNorm(ctx context.Context, in *VectorRequest, opts ...grpc.CallOption) (*ScalarResponse, error)
+	// the matrix times the vector taken as a column
+	MatrixVectorMultiply(ctx context.Context, in *MatrixVectorMultiplyRequest, opts ...grpc.CallOption) (*VectorResponse, error)
+	// INVALID_ARGUMENT for unknown units or units of different dimensions,
+	// such as km/h and kg
+	Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error)
+}
+
+type calculatorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCalculatorServiceClient(cc grpc.ClientConnInterface) CalculatorServiceClient {
+	return &calculatorServiceClient{cc}
+}
+
+func (c *calculatorServiceClient) Sum(ctx context.Context, in *SumRequest, opts ...grpc.CallOption) (*SumResponse, error) {
+	out := new(SumResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Sum", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) PrimeNumberDecomposition(ctx context.Context, in *PrimeNumberDecompositionRequest, opts ...grpc.CallOption) (CalculatorService_PrimeNumberDecompositionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[0], "/calculator.CalculatorService/PrimeNumberDecomposition", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServicePrimeNumberDecompositionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CalculatorService_PrimeNumberDecompositionClient interface {
+	Recv() (*PrimeNumberDecompositionResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServicePrimeNumberDecompositionClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServicePrimeNumberDecompositionClient) Recv() (*PrimeNumberDecompositionResponse, error) {
+	m := new(PrimeNumberDecompositionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) ComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeAverageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[1], "/calculator.CalculatorService/ComputeAverage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceComputeAverageClient{stream}
+	return x, nil
+}
+
+type CalculatorService_ComputeAverageClient interface {
+	Send(*ComputeAverageRequest) error
+	CloseAndRecv() (*ComputeAverageResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceComputeAverageClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceComputeAverageClient) Send(m *ComputeAverageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceComputeAverageClient) CloseAndRecv() (*ComputeAverageResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ComputeAverageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) FindMaximum(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_FindMaximumClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[2], "/calculator.CalculatorService/FindMaximum", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceFindMaximumClient{stream}
+	return x, nil
+}
+
+type CalculatorService_FindMaximumClient interface {
+	Send(*FindMaximumRequest) error
+	Recv() (*FindMaximumResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceFindMaximumClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceFindMaximumClient) Send(m *FindMaximumRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceFindMaximumClient) Recv() (*FindMaximumResponse, error) {
+	m := new(FindMaximumResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) SquareRoot(ctx context.Context, in *SquareRootRequest, opts ...grpc.CallOption) (*SquareRootResponse, error) {
+	out := new(SquareRootResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/SquareRoot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) BigSum(ctx context.Context, in *BigSumRequest, opts ...grpc.CallOption) (*BigSumResponse, error) {
+	out := new(BigSumResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/BigSum", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) BigComputeAverage(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_BigComputeAverageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[3], "/calculator.CalculatorService/BigComputeAverage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceBigComputeAverageClient{stream}
+	return x, nil
+}
+
+type CalculatorService_BigComputeAverageClient interface {
+	Send(*BigComputeAverageRequest) error
+	CloseAndRecv() (*BigComputeAverageResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceBigComputeAverageClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceBigComputeAverageClient) Send(m *BigComputeAverageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceBigComputeAverageClient) CloseAndRecv() (*BigComputeAverageResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(BigComputeAverageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) Add(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error) {
+	out := new(NumberResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Add", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Subtract(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error) {
+	out := new(NumberResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Subtract", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Multiply(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error) {
+	out := new(NumberResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Multiply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Divide(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error) {
+	out := new(NumberResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Divide", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Modulo(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error) {
+	out := new(NumberResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Modulo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Power(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error) {
+	out := new(NumberResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Power", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) GCD(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error) {
+	out := new(NumberResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/GCD", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) LCM(ctx context.Context, in *BinaryOperationRequest, opts ...grpc.CallOption) (*NumberResponse, error) {
+	out := new(NumberResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/LCM", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Factorial(ctx context.Context, in *FactorialRequest, opts ...grpc.CallOption) (*FactorialResponse, error) {
+	out := new(FactorialResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Factorial", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Evaluate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) IsPrime(ctx context.Context, in *IsPrimeRequest, opts ...grpc.CallOption) (*IsPrimeResponse, error) {
+	out := new(IsPrimeResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/IsPrime", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) NextPrime(ctx context.Context, in *NextPrimeRequest, opts ...grpc.CallOption) (*NextPrimeResponse, error) {
+	out := new(NextPrimeResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/NextPrime", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) PrimesInRange(ctx context.Context, in *PrimesInRangeRequest, opts ...grpc.CallOption) (CalculatorService_PrimesInRangeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[4], "/calculator.CalculatorService/PrimesInRange", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServicePrimesInRangeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CalculatorService_PrimesInRangeClient interface {
+	Recv() (*PrimesInRangeResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServicePrimesInRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServicePrimesInRangeClient) Recv() (*PrimesInRangeResponse, error) {
+	m := new(PrimesInRangeResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) ComputeStatistics(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_ComputeStatisticsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[5], "/calculator.CalculatorService/ComputeStatistics", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceComputeStatisticsClient{stream}
+	return x, nil
+}
+
+type CalculatorService_ComputeStatisticsClient interface {
+	Send(*ComputeStatisticsRequest) error
+	CloseAndRecv() (*ComputeStatisticsResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceComputeStatisticsClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceComputeStatisticsClient) Send(m *ComputeStatisticsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceComputeStatisticsClient) CloseAndRecv() (*ComputeStatisticsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ComputeStatisticsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) RunningAggregate(ctx context.Context, opts ...grpc.CallOption) (CalculatorService_RunningAggregateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CalculatorService_serviceDesc.Streams[6], "/calculator.CalculatorService/RunningAggregate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &calculatorServiceRunningAggregateClient{stream}
+	return x, nil
+}
+
+type CalculatorService_RunningAggregateClient interface {
+	Send(*RunningAggregateRequest) error
+	Recv() (*RunningAggregateResponse, error)
+	grpc.ClientStream
+}
+
+type calculatorServiceRunningAggregateClient struct {
+	grpc.ClientStream
+}
+
+func (x *calculatorServiceRunningAggregateClient) Send(m *RunningAggregateRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *calculatorServiceRunningAggregateClient) Recv() (*RunningAggregateResponse, error) {
+	m := new(RunningAggregateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *calculatorServiceClient) MatrixAdd(ctx context.Context, in *MatrixPairRequest, opts ...grpc.CallOption) (*MatrixResponse, error) {
+	out := new(MatrixResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/MatrixAdd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) MatrixMultiply(ctx context.Context, in *MatrixPairRequest, opts ...grpc.CallOption) (*MatrixResponse, error) {
+	out := new(MatrixResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/MatrixMultiply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) MatrixTranspose(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*MatrixResponse, error) {
+	out := new(MatrixResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/MatrixTranspose", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Determinant(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*DeterminantResponse, error) {
+	out := new(DeterminantResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Determinant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) MatrixInverse(ctx context.Context, in *MatrixRequest, opts ...grpc.CallOption) (*MatrixResponse, error) {
+	out := new(MatrixResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/MatrixInverse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) SolveLinearSystem(ctx context.Context, in *SolveLinearSystemRequest, opts ...grpc.CallOption) (*SolveLinearSystemResponse, error) {
+	out := new(SolveLinearSystemResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/SolveLinearSystem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) VectorAdd(ctx context.Context, in *VectorPairRequest, opts ...grpc.CallOption) (*VectorResponse, error) {
+	out := new(VectorResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/VectorAdd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) DotProduct(ctx context.Context, in *VectorPairRequest, opts ...grpc.CallOption) (*ScalarResponse, error) {
+	out := new(ScalarResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/DotProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) CrossProduct(ctx context.Context, in *VectorPairRequest, opts ...grpc.CallOption) (*VectorResponse, error) {
+	out := new(VectorResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/CrossProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) VectorNorm(ctx context.Context, in *VectorRequest, opts ...grpc.CallOption) (*ScalarResponse, error) {
+	out := new(ScalarResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/VectorNorm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) MatrixVectorMultiply(ctx context.Context, in *MatrixVectorMultiplyRequest, opts ...grpc.CallOption) (*VectorResponse, error) {
+	out := new(VectorResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/MatrixVectorMultiply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorServiceClient) Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*ConvertResponse, error) {
+	out := new(ConvertResponse)
+	err := c.cc.Invoke(ctx, "/calculator.CalculatorService/Convert", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CalculatorServiceServer is the server API for CalculatorService service.
+type CalculatorServiceServer interface {
+	// unary api, fails with OUT_OF_RANGE when the sum does not fit in int32
+	Sum(context.Context, *SumRequest) (*SumResponse, error)
+	// Stream of the prime factors in ascending order, each repeated as often
+	// as it divides number. A negative number starts with -1 so the factors
+	// multiply to it, 1 has no factors and 0 fails with INVALID_ARGUMENT.
+	PrimeNumberDecomposition(*PrimeNumberDecompositionRequest, CalculatorService_PrimeNumberDecompositionServer) error
+	// Client Stream, INVALID_ARGUMENT when no numbers are sent;
+	// superseded by ComputeStatistics
+	ComputeAverage(CalculatorService_ComputeAverageServer) error
+	// BI Stream, reports every new maximum starting with the first number
+	FindMaximum(CalculatorService_FindMaximumServer) error
+	SquareRoot(context.Context, *SquareRootRequest) (*SquareRootResponse, error)
+	// arbitrary-precision variants of Sum and ComputeAverage
+	BigSum(context.Context, *BigSumRequest) (*BigSumResponse, error)
+	BigComputeAverage(CalculatorService_BigComputeAverageServer) error
+	// arithmetic on Numbers: INVALID_ARGUMENT for a missing or non-finite
+	// operand or a zero divisor, OUT_OF_RANGE when the result overflows.
+	// Integer results beyond int64 are available as decimals by sending
+	// decimal operands
+	Add(context.Context, *BinaryOperationRequest) (*NumberResponse, error)
+	Subtract(context.Context, *BinaryOperationRequest) (*NumberResponse, error)
+	Multiply(context.Context, *BinaryOperationRequest) (*NumberResponse, error)
+	// integer when the quotient is exact, real otherwise
+	Divide(context.Context, *BinaryOperationRequest) (*NumberResponse, error)
+	// the result has the sign of the first operand
+	Modulo(context.Context, *BinaryOperationRequest) (*NumberResponse, error)
+	// decimal powers take an integer exponent
+	Power(context.Context, *BinaryOperationRequest) (*NumberResponse, error)
+	// GCD, LCM and Factorial take integers, or reals with no fractional part
+	GCD(context.Context, *BinaryOperationRequest) (*NumberResponse, error)
+	LCM(context.Context, *BinaryOperationRequest) (*NumberResponse, error)
+	Factorial(context.Context, *FactorialRequest) (*FactorialResponse, error)
+	// INVALID_ARGUMENT for expressions that do not parse or evaluate, with the
+	// 1-based column of the problem in the error details
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	// deterministic for every int64, negative numbers are not prime
+	IsPrime(context.Context, *IsPrimeRequest) (*IsPrimeResponse, error)
+	// the smallest prime greater than number, OUT_OF_RANGE past the
+	// largest int64 prime
+	NextPrime(context.Context, *NextPrimeRequest) (*NextPrimeResponse, error)
+	// INVALID_ARGUMENT when the range is reversed or larger than the server allows
+	PrimesInRange(*PrimesInRangeRequest, CalculatorService_PrimesInRangeServer) error
+	// INVALID_ARGUMENT when no numbers are sent
+	ComputeStatistics(CalculatorService_ComputeStatisticsServer) error
+	RunningAggregate(CalculatorService_RunningAggregateServer) error
+	// linear algebra: INVALID_ARGUMENT when the dimensions do not fit the
+	// operation, OUT_OF_RANGE when a result overflows
+	MatrixAdd(context.Context, *MatrixPairRequest) (*MatrixResponse, error)
+	MatrixMultiply(context.Context, *MatrixPairRequest) (*MatrixResponse, error)
+	MatrixTranspose(context.Context, *MatrixRequest) (*MatrixResponse, error)
+	Determinant(context.Context, *MatrixRequest) (*DeterminantResponse, error)
+	// INVALID_ARGUMENT for a singular matrix
+	MatrixInverse(context.Context, *MatrixRequest) (*MatrixResponse, error)
+	// INVALID_ARGUMENT when the system has no unique solution
+	SolveLinearSystem(context.Context, *SolveLinearSystemRequest) (*SolveLinearSystemResponse, error)
+	VectorAdd(context.Context, *VectorPairRequest) (*VectorResponse, error)
+	DotProduct(context.Context, *VectorPairRequest) (*ScalarResponse, error)
+	// only defined for vectors of three values
+	CrossProduct(context.Context, *VectorPairRequest) (*VectorResponse, error)
+	// the Euclidean length of the vector
+	VectorNorm(context.Context, *VectorRequest) (*ScalarResponse, error)
+	// the matrix times the vector taken as a column
+	MatrixVectorMultiply(context.Context, *MatrixVectorMultiplyRequest) (*VectorResponse, error)
+	// INVALID_ARGUMENT for unknown units or units of different dimensions,
+	// such as km/h and kg
+	Convert(context.Context, *ConvertRequest) (*ConvertResponse, error)
+}
+
+// UnimplementedCalculatorServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedCalculatorServiceServer struct {
+}
+
+func (*UnimplementedCalculatorServiceServer) Sum(ctx context.Context, req *SumRequest) (*SumResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sum not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) PrimeNumberDecomposition(req *PrimeNumberDecompositionRequest, srv CalculatorService_PrimeNumberDecompositionServer) error {
+	return status.Errorf(codes.Unimplemented, "method PrimeNumberDecomposition not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) ComputeAverage(srv CalculatorService_ComputeAverageServer) error {
+	return status.Errorf(codes.Unimplemented, "method ComputeAverage not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) FindMaximum(srv CalculatorService_FindMaximumServer) error {
+	return status.Errorf(codes.Unimplemented, "method FindMaximum not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) SquareRoot(ctx context.Context, req *SquareRootRequest) (*SquareRootResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SquareRoot not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) BigSum(ctx context.Context, req *BigSumRequest) (*BigSumResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BigSum not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) BigComputeAverage(srv CalculatorService_BigComputeAverageServer) error {
+	return status.Errorf(codes.Unimplemented, "method BigComputeAverage not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Add(ctx context.Context, req *BinaryOperationRequest) (*NumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Add not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Subtract(ctx context.Context, req *BinaryOperationRequest) (*NumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Subtract not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Multiply(ctx context.Context, req *BinaryOperationRequest) (*NumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Multiply not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Divide(ctx context.Context, req *BinaryOperationRequest) (*NumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Divide not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Modulo(ctx context.Context, req *BinaryOperationRequest) (*NumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Modulo not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Power(ctx context.Context, req *BinaryOperationRequest) (*NumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Power not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) GCD(ctx context.Context, req *BinaryOperationRequest) (*NumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GCD not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) LCM(ctx context.Context, req *BinaryOperationRequest) (*NumberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LCM not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Factorial(ctx context.Context, req *FactorialRequest) (*FactorialResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Factorial not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Evaluate(ctx context.Context, req *EvaluateRequest) (*EvaluateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) IsPrime(ctx context.Context, req *IsPrimeRequest) (*IsPrimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsPrime not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) NextPrime(ctx context.Context, req *NextPrimeRequest) (*NextPrimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextPrime not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) PrimesInRange(req *PrimesInRangeRequest, srv CalculatorService_PrimesInRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method PrimesInRange not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) ComputeStatistics(srv CalculatorService_ComputeStatisticsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ComputeStatistics not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) RunningAggregate(srv CalculatorService_RunningAggregateServer) error {
+	return status.Errorf(codes.Unimplemented, "method RunningAggregate not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) MatrixAdd(ctx context.Context, req *MatrixPairRequest) (*MatrixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MatrixAdd not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) MatrixMultiply(ctx context.Context, req *MatrixPairRequest) (*MatrixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MatrixMultiply not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) MatrixTranspose(ctx context.Context, req *MatrixRequest) (*MatrixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MatrixTranspose not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Determinant(ctx context.Context, req *MatrixRequest) (*DeterminantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Determinant not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) MatrixInverse(ctx context.Context, req *MatrixRequest) (*MatrixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MatrixInverse not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) SolveLinearSystem(ctx context.Context, req *SolveLinearSystemRequest) (*SolveLinearSystemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SolveLinearSystem not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) VectorAdd(ctx context.Context, req *VectorPairRequest) (*VectorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VectorAdd not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) DotProduct(ctx context.Context, req *VectorPairRequest) (*ScalarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DotProduct not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) CrossProduct(ctx context.Context, req *VectorPairRequest) (*VectorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CrossProduct not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) VectorNorm(ctx context.Context, req *VectorRequest) (*ScalarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VectorNorm not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) MatrixVectorMultiply(ctx context.Context, req *MatrixVectorMultiplyRequest) (*VectorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MatrixVectorMultiply not implemented")
+}
+func (*UnimplementedCalculatorServiceServer) Convert(ctx context.Context, req *ConvertRequest) (*ConvertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Convert not implemented")
+}
+
+func RegisterCalculatorServiceServer(s *grpc.Server, srv CalculatorServiceServer) {
+	s.RegisterService(&_CalculatorService_serviceDesc, srv)
+}
+
+func _CalculatorService_Sum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Sum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Sum",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Sum(ctx, req.(*SumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_PrimeNumberDecomposition_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PrimeNumberDecompositionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalculatorServiceServer).PrimeNumberDecomposition(m, &calculatorServicePrimeNumberDecompositionServer{stream})
+}
+
+type CalculatorService_PrimeNumberDecompositionServer interface {
+	Send(*PrimeNumberDecompositionResponse) error
+	grpc.ServerStream
+}
+
+type calculatorServicePrimeNumberDecompositionServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServicePrimeNumberDecompositionServer) Send(m *PrimeNumberDecompositionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CalculatorService_ComputeAverage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).ComputeAverage(&calculatorServiceComputeAverageServer{stream})
+}
+
+type CalculatorService_ComputeAverageServer interface {
+	SendAndClose(*ComputeAverageResponse) error
+	Recv() (*ComputeAverageRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceComputeAverageServer struct {
+	grpc.ServerStream
 }
 
 func (x *calculatorServiceComputeAverageServer) SendAndClose(m *ComputeAverageResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *calculatorServiceComputeAverageServer) Recv() (*ComputeAverageRequest, error) {
-	m := new(ComputeAverageRequest)
+func (x *calculatorServiceComputeAverageServer) Recv() (*ComputeAverageRequest, error) {
+	m := new(ComputeAverageRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _CalculatorService_FindMaximum_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).FindMaximum(&calculatorServiceFindMaximumServer{stream})
+}
+
+type CalculatorService_FindMaximumServer interface {
+	Send(*FindMaximumResponse) error
+	Recv() (*FindMaximumRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceFindMaximumServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceFindMaximumServer) Send(m *FindMaximumResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceFindMaximumServer) Recv() (*FindMaximumRequest, error) {
+	m := new(FindMaximumRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _CalculatorService_SquareRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SquareRootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).SquareRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/SquareRoot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).SquareRoot(ctx, req.(*SquareRootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_BigSum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BigSumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).BigSum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/BigSum",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).BigSum(ctx, req.(*BigSumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_BigComputeAverage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).BigComputeAverage(&calculatorServiceBigComputeAverageServer{stream})
+}
+
+type CalculatorService_BigComputeAverageServer interface {
+	SendAndClose(*BigComputeAverageResponse) error
+	Recv() (*BigComputeAverageRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceBigComputeAverageServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceBigComputeAverageServer) SendAndClose(m *BigComputeAverageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceBigComputeAverageServer) Recv() (*BigComputeAverageRequest, error) {
+	m := new(BigComputeAverageRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _CalculatorService_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BinaryOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Add(ctx, req.(*BinaryOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Subtract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BinaryOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Subtract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Subtract",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Subtract(ctx, req.(*BinaryOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Multiply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BinaryOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Multiply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Multiply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Multiply(ctx, req.(*BinaryOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Divide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BinaryOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Divide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Divide",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Divide(ctx, req.(*BinaryOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Modulo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BinaryOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Modulo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Modulo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Modulo(ctx, req.(*BinaryOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Power_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BinaryOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Power(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Power",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Power(ctx, req.(*BinaryOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_GCD_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BinaryOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).GCD(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/GCD",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).GCD(ctx, req.(*BinaryOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_LCM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BinaryOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).LCM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/LCM",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).LCM(ctx, req.(*BinaryOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Factorial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FactorialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Factorial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Factorial",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Factorial(ctx, req.(*FactorialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Evaluate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_IsPrime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IsPrimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).IsPrime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/IsPrime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).IsPrime(ctx, req.(*IsPrimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_NextPrime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextPrimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).NextPrime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/NextPrime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).NextPrime(ctx, req.(*NextPrimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_PrimesInRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PrimesInRangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalculatorServiceServer).PrimesInRange(m, &calculatorServicePrimesInRangeServer{stream})
+}
+
+type CalculatorService_PrimesInRangeServer interface {
+	Send(*PrimesInRangeResponse) error
+	grpc.ServerStream
+}
+
+type calculatorServicePrimesInRangeServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServicePrimesInRangeServer) Send(m *PrimesInRangeResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _CalculatorService_ComputeStatistics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).ComputeStatistics(&calculatorServiceComputeStatisticsServer{stream})
+}
+
+type CalculatorService_ComputeStatisticsServer interface {
+	SendAndClose(*ComputeStatisticsResponse) error
+	Recv() (*ComputeStatisticsRequest, error)
+	grpc.ServerStream
+}
+
+type calculatorServiceComputeStatisticsServer struct {
+	grpc.ServerStream
+}
+
+func (x *calculatorServiceComputeStatisticsServer) SendAndClose(m *ComputeStatisticsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *calculatorServiceComputeStatisticsServer) Recv() (*ComputeStatisticsRequest, error) {
+	m := new(ComputeStatisticsRequest)
 	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func _CalculatorService_FindMaximum_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(CalculatorServiceServer).FindMaximum(&calculatorServiceFindMaximumServer{stream})
+func _CalculatorService_RunningAggregate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalculatorServiceServer).RunningAggregate(&calculatorServiceRunningAggregateServer{stream})
 }
 
-type CalculatorService_FindMaximumServer interface {
-	Send(*FindMaximumResponse) error
-	Recv() (*FindMaximumRequest, error)
+type CalculatorService_RunningAggregateServer interface {
+	Send(*RunningAggregateResponse) error
+	Recv() (*RunningAggregateRequest, error)
 	grpc.ServerStream
 }
 
-type calculatorServiceFindMaximumServer struct {
+type calculatorServiceRunningAggregateServer struct {
 	grpc.ServerStream
 }
 
-func (x *calculatorServiceFindMaximumServer) Send(m *FindMaximumResponse) error {
+func (x *calculatorServiceRunningAggregateServer) Send(m *RunningAggregateResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *calculatorServiceFindMaximumServer) Recv() (*FindMaximumRequest, error) {
-	m := new(FindMaximumRequest)
+func (x *calculatorServiceRunningAggregateServer) Recv() (*RunningAggregateRequest, error) {
+	m := new(RunningAggregateRequest)
 	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
 	return m, nil
 }
 
-func _CalculatorService_SquareRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SquareRootRequest)
+func _CalculatorService_MatrixAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatrixPairRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CalculatorServiceServer).SquareRoot(ctx, in)
+		return srv.(CalculatorServiceServer).MatrixAdd(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/calculator.CalculatorService/SquareRoot",
+		FullMethod: "/calculator.CalculatorService/MatrixAdd",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CalculatorServiceServer).SquareRoot(ctx, req.(*SquareRootRequest))
+		return srv.(CalculatorServiceServer).MatrixAdd(ctx, req.(*MatrixPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_MatrixMultiply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatrixPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).MatrixMultiply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/MatrixMultiply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).MatrixMultiply(ctx, req.(*MatrixPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_MatrixTranspose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).MatrixTranspose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/MatrixTranspose",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).MatrixTranspose(ctx, req.(*MatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Determinant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Determinant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Determinant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Determinant(ctx, req.(*MatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_MatrixInverse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatrixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).MatrixInverse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/MatrixInverse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).MatrixInverse(ctx, req.(*MatrixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_SolveLinearSystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SolveLinearSystemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).SolveLinearSystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/SolveLinearSystem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).SolveLinearSystem(ctx, req.(*SolveLinearSystemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_VectorAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VectorPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).VectorAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/VectorAdd",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).VectorAdd(ctx, req.(*VectorPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_DotProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VectorPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).DotProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/DotProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).DotProduct(ctx, req.(*VectorPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_CrossProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VectorPairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).CrossProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/CrossProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).CrossProduct(ctx, req.(*VectorPairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_VectorNorm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VectorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).VectorNorm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/VectorNorm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).VectorNorm(ctx, req.(*VectorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_MatrixVectorMultiply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatrixVectorMultiplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).MatrixVectorMultiply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/MatrixVectorMultiply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).MatrixVectorMultiply(ctx, req.(*MatrixVectorMultiplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CalculatorService_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServiceServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/calculator.CalculatorService/Convert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServiceServer).Convert(ctx, req.(*ConvertRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -774,6 +3901,106 @@ var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SquareRoot",
 			Handler:    _CalculatorService_SquareRoot_Handler,
 		},
+		{
+			MethodName: "BigSum",
+			Handler:    _CalculatorService_BigSum_Handler,
+		},
+		{
+			MethodName: "Add",
+			Handler:    _CalculatorService_Add_Handler,
+		},
+		{
+			MethodName: "Subtract",
+			Handler:    _CalculatorService_Subtract_Handler,
+		},
+		{
+			MethodName: "Multiply",
+			Handler:    _CalculatorService_Multiply_Handler,
+		},
+		{
+			MethodName: "Divide",
+			Handler:    _CalculatorService_Divide_Handler,
+		},
+		{
+			MethodName: "Modulo",
+			Handler:    _CalculatorService_Modulo_Handler,
+		},
+		{
+			MethodName: "Power",
+			Handler:    _CalculatorService_Power_Handler,
+		},
+		{
+			MethodName: "GCD",
+			Handler:    _CalculatorService_GCD_Handler,
+		},
+		{
+			MethodName: "LCM",
+			Handler:    _CalculatorService_LCM_Handler,
+		},
+		{
+			MethodName: "Factorial",
+			Handler:    _CalculatorService_Factorial_Handler,
+		},
+		{
+			MethodName: "Evaluate",
+			Handler:    _CalculatorService_Evaluate_Handler,
+		},
+		{
+			MethodName: "IsPrime",
+			Handler:    _CalculatorService_IsPrime_Handler,
+		},
+		{
+			MethodName: "NextPrime",
+			Handler:    _CalculatorService_NextPrime_Handler,
+		},
+		{
+			MethodName: "MatrixAdd",
+			Handler:    _CalculatorService_MatrixAdd_Handler,
+		},
+		{
+			MethodName: "MatrixMultiply",
+			Handler:    _CalculatorService_MatrixMultiply_Handler,
+		},
+		{
+			MethodName: "MatrixTranspose",
+			Handler:    _CalculatorService_MatrixTranspose_Handler,
+		},
+		{
+			MethodName: "Determinant",
+			Handler:    _CalculatorService_Determinant_Handler,
+		},
+		{
+			MethodName: "MatrixInverse",
+			Handler:    _CalculatorService_MatrixInverse_Handler,
+		},
+		{
+			MethodName: "SolveLinearSystem",
+			Handler:    _CalculatorService_SolveLinearSystem_Handler,
+		},
+		{
+			MethodName: "VectorAdd",
+			Handler:    _CalculatorService_VectorAdd_Handler,
+		},
+		{
+			MethodName: "DotProduct",
+			Handler:    _CalculatorService_DotProduct_Handler,
+		},
+		{
+			MethodName: "CrossProduct",
+			Handler:    _CalculatorService_CrossProduct_Handler,
+		},
+		{
+			MethodName: "VectorNorm",
+			Handler:    _CalculatorService_VectorNorm_Handler,
+		},
+		{
+			MethodName: "MatrixVectorMultiply",
+			Handler:    _CalculatorService_MatrixVectorMultiply_Handler,
+		},
+		{
+			MethodName: "Convert",
+			Handler:    _CalculatorService_Convert_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -792,6 +4019,27 @@ var _CalculatorService_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "BigComputeAverage",
+			Handler:       _CalculatorService_BigComputeAverage_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "PrimesInRange",
+			Handler:       _CalculatorService_PrimesInRange_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ComputeStatistics",
+			Handler:       _CalculatorService_ComputeStatistics_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "RunningAggregate",
+			Handler:       _CalculatorService_RunningAggregate_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "calculator-app/calculatorpb/calculator.proto",
 }
@@ -0,0 +1,125 @@
+// Package certs builds TLS credentials for the servers and clients and
+// identifies the peer behind a mutual TLS connection.
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/serhii12/grpc-go/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// ServerOptions returns the grpc options that make a server use cfg.
+// It returns no options when TLS is disabled. With a reload interval set,
+// the certificate files are watched for the life of the process.
+func ServerOptions(cfg config.TLS) ([]grpc.ServerOption, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	r, err := NewReloader(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.ReloadInterval > 0 {
+		go r.Watch(cfg.ReloadInterval, nil)
+	}
+
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(r.TLSConfig()))}, nil
+}
+
+// LoadCertPool reads a PEM bundle of CA certificates
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read CA bundle: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+
+	return pool, nil
+}
+
+// ClientConfig configures transport security for a client
+type ClientConfig struct {
+	Enabled    bool
+	CAFile     string
+	CertFile   string
+	KeyFile    string
+	ServerName string
+}
+
+// RegisterFlags binds the client TLS settings to flags in fs
+func (c *ClientConfig) RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&c.Enabled, "tls", false, "connect over TLS")
+	fs.StringVar(&c.CAFile, "tls-ca", "ssl/ca.crt", "PEM CA bundle used to verify the server")
+	fs.StringVar(&c.CertFile, "tls-cert", "", "PEM client certificate for mutual TLS")
+	fs.StringVar(&c.KeyFile, "tls-key", "", "PEM client private key for mutual TLS")
+	fs.StringVar(&c.ServerName, "tls-server-name", "", "override the server name checked against its certificate")
+}
+
+// DialOption returns the transport credentials for c,
+// or an insecure transport when TLS is disabled
+func (c ClientConfig) DialOption() (grpc.DialOption, error) {
+	if !c.Enabled {
+		return grpc.WithInsecure(), nil
+	}
+
+	pool, err := LoadCertPool(c.CAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:    pool,
+		ServerName: c.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client key pair: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), nil
+}
+
+// PrincipalFromContext returns the identity of a client authenticated with
+// a verified certificate: its common name, or its first DNS or email SAN.
+func PrincipalFromContext(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	cert := info.State.VerifiedChains[0][0]
+	switch {
+	case cert.Subject.CommonName != "":
+		return cert.Subject.CommonName, true
+	case len(cert.DNSNames) > 0:
+		return cert.DNSNames[0], true
+	case len(cert.EmailAddresses) > 0:
+		return cert.EmailAddresses[0], true
+	}
+
+	return "", false
+}
@@ -0,0 +1,221 @@
+package certs
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/serhii12/grpc-go/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// writePair writes p to dir and returns the certificate and key paths
+func writePair(t *testing.T, dir, name string, p *KeyPair) (string, string) {
+	t.Helper()
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".pem")
+	if err := os.WriteFile(certFile, p.CertPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, p.KeyPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+// startMutualTLSServer serves the health service over mutual TLS and
+// reports the principal of every call on the returned channel
+func startMutualTLSServer(t *testing.T, tlsCfg config.TLS) (*bufconn.Listener, <-chan string) {
+	t.Helper()
+	opts, err := ServerOptions(tlsCfg)
+	if err != nil {
+		t.Fatalf("ServerOptions: %v", err)
+	}
+
+	principals := make(chan string, 1)
+	opts = append(opts, grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		principal, _ := PrincipalFromContext(ctx)
+		principals <- principal
+		return handler(ctx, req)
+	}))
+
+	s := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	lis := bufconn.Listen(1 << 20)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	return lis, principals
+}
+
+func check(t *testing.T, lis *bufconn.Listener, cfg ClientConfig) error {
+	t.Helper()
+	opt, err := cfg.DialOption()
+	if err != nil {
+		t.Fatalf("DialOption: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, "bufnet", opt,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+
+	ca, err := NewAuthority("test CA", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := ca.IssueServer("localhost", []string{"localhost", "127.0.0.1"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := ca.IssueClient("team-a", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a client certificate from a CA the server does not trust
+	otherCA, err := NewAuthority("other CA", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stranger, err := otherCA.IssueClient("team-b", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caFile := filepath.Join(dir, "ca.crt")
+	if err := os.WriteFile(caFile, ca.CertPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	serverCert, serverKey := writePair(t, dir, "server", server)
+	clientCert, clientKey := writePair(t, dir, "client", client)
+	strangerCert, strangerKey := writePair(t, dir, "stranger", stranger)
+
+	lis, principals := startMutualTLSServer(t, config.TLS{
+		Enabled:    true,
+		CertFile:   serverCert,
+		KeyFile:    serverKey,
+		ClientCA:   caFile,
+		ClientAuth: true,
+	})
+
+	tests := []struct {
+		name          string
+		cfg           ClientConfig
+		wantPrincipal string
+		wantErr       bool
+	}{
+		{
+			name:          "trusted client certificate",
+			cfg:           ClientConfig{Enabled: true, CAFile: caFile, CertFile: clientCert, KeyFile: clientKey, ServerName: "localhost"},
+			wantPrincipal: "team-a",
+		},
+		{
+			name:    "no client certificate",
+			cfg:     ClientConfig{Enabled: true, CAFile: caFile, ServerName: "localhost"},
+			wantErr: true,
+		},
+		{
+			name:    "client certificate from another CA",
+			cfg:     ClientConfig{Enabled: true, CAFile: caFile, CertFile: strangerCert, KeyFile: strangerKey, ServerName: "localhost"},
+			wantErr: true,
+		},
+		{
+			name:    "server name not in its certificate",
+			cfg:     ClientConfig{Enabled: true, CAFile: caFile, CertFile: clientCert, KeyFile: clientKey, ServerName: "example.com"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := check(t, lis, tt.cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected the handshake to fail")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Check: %v", err)
+			}
+
+			if got := <-principals; got != tt.wantPrincipal {
+				t.Errorf("principal = %q, want %q", got, tt.wantPrincipal)
+			}
+		})
+	}
+}
+
+func TestPrincipalFromContextWithoutPeer(t *testing.T) {
+	if p, ok := PrincipalFromContext(context.Background()); ok {
+		t.Errorf("got principal %q from a context without a peer", p)
+	}
+}
+
+// Reload may be called while Watch polls the files, run with -race
+func TestReloadWhileWatching(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := NewAuthority("test CA", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issue := func(commonName string) {
+		t.Helper()
+		pair, err := ca.IssueServer(commonName, []string{"localhost"}, time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writePair(t, dir, "server", pair)
+	}
+
+	issue("first")
+	r, err := NewReloader(config.TLS{
+		Enabled:  true,
+		CertFile: filepath.Join(dir, "server.crt"),
+		KeyFile:  filepath.Join(dir, "server.pem"),
+	})
+	if err != nil {
+		t.Fatalf("NewReloader: %v", err)
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go r.Watch(10*time.Millisecond, stop)
+
+	time.Sleep(20 * time.Millisecond)
+	issue("second")
+	if err := r.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for r.load().cert.Leaf.Subject.CommonName != "second" {
+		if time.Now().After(deadline) {
+			t.Fatal("the rotated certificate was never loaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
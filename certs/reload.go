@@ -0,0 +1,175 @@
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/serhii12/grpc-go/config"
+)
+
+// bundle is the set of credentials swapped in one step on reload
+type bundle struct {
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+// Reloader serves the most recently loaded server key pair and client CA
+// bundle, so certificates can be rotated without restarting the server.
+type Reloader struct {
+	cfg     config.TLS
+	current atomic.Value // *bundle
+
+	// mu serializes reloads and guards stamps, which Watch reads
+	mu     sync.Mutex
+	stamps map[string]fileStamp
+}
+
+// fileStamp identifies a version of a watched file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewReloader loads the credentials named by cfg
+func NewReloader(cfg config.TLS) (*Reloader, error) {
+	r := &Reloader{cfg: cfg}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// TLSConfig returns a server configuration that picks up the current
+// credentials on every handshake
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			b := r.load()
+			c := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*b.cert},
+				NextProtos:   []string{"h2"},
+			}
+			if r.cfg.ClientAuth {
+				c.ClientCAs = b.clientCAs
+				c.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return c, nil
+		},
+	}
+}
+
+func (r *Reloader) load() *bundle {
+	return r.current.Load().(*bundle)
+}
+
+// Reload reads the certificate files and swaps them in. On error the
+// previous credentials stay in use.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stamps, err := r.stat()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load server key pair: %v", err)
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("parse server certificate: %v", err)
+	}
+	cert.Leaf = leaf
+
+	b := &bundle{cert: &cert}
+	if r.cfg.ClientAuth {
+		if b.clientCAs, err = LoadCertPool(r.cfg.ClientCA); err != nil {
+			return err
+		}
+	}
+
+	r.current.Store(b)
+	r.stamps = stamps
+	slog.Info("Loaded certificate",
+		"file", r.cfg.CertFile,
+		"subject", leaf.Subject.CommonName,
+		"expires", leaf.NotAfter.Format(time.RFC3339),
+	)
+
+	return nil
+}
+
+// Watch checks the certificate files every interval and reloads them when
+// any of them changes, until stop is closed
+func (r *Reloader) Watch(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		stamps, err := r.stat()
+		if err != nil {
+			slog.Error("Failed to check certificate files", "error", err)
+			continue
+		}
+		if r.unchanged(stamps) {
+			continue
+		}
+
+		if err := r.Reload(); err != nil {
+			slog.Error("Failed to reload certificates, keeping the previous ones", "error", err)
+		}
+	}
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientAuth {
+		files = append(files, r.cfg.ClientCA)
+	}
+
+	return files
+}
+
+func (r *Reloader) stat() (map[string]fileStamp, error) {
+	stamps := map[string]fileStamp{}
+	for _, f := range r.files() {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return nil, err
+		}
+		stamps[f] = fileStamp{modTime: fi.ModTime(), size: fi.Size()}
+	}
+
+	return stamps, nil
+}
+
+func (r *Reloader) unchanged(stamps map[string]fileStamp) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for f, s := range stamps {
+		if r.stamps[f] != s {
+			return false
+		}
+	}
+
+	return true
+}
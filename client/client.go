@@ -0,0 +1,81 @@
+// Package client connects the example clients to their servers with the
+// TLS, tracing and request ID setup they share.
+package client
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/serhii12/grpc-go/certs"
+	"github.com/serhii12/grpc-go/config"
+	"github.com/serhii12/grpc-go/requestid"
+	"github.com/serhii12/grpc-go/tracing"
+	"google.golang.org/grpc"
+)
+
+// Config holds the settings every client takes as flags
+type Config struct {
+	TLS     certs.ClientConfig
+	Tracing config.Tracing
+}
+
+// RegisterFlags binds the TLS and tracing settings to flags in fs
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	c.TLS.RegisterFlags(fs)
+	tracing.RegisterFlags(fs, &c.Tracing)
+}
+
+// Conn is a client connection that also flushes the client's spans when
+// it is closed
+type Conn struct {
+	*grpc.ClientConn
+	stopTracing func(context.Context) error
+}
+
+// Dial sets up tracing as service and connects to address, blocking until
+// the connection is up. Every call sends a request ID, which is printed so
+// it can be quoted when looking the call up in the server logs.
+func Dial(service, address string, cfg Config, opts ...grpc.DialOption) (*Conn, error) {
+	creds, err := cfg.TLS.DialOption()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS credentials: %v", err)
+	}
+
+	stopTracing, err := tracing.Setup(context.Background(), service, cfg.Tracing)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append([]grpc.DialOption{creds, grpc.WithBlock(),
+		grpc.WithChainUnaryInterceptor(requestid.UnaryClientInterceptor(printRequestID)),
+		grpc.WithChainStreamInterceptor(requestid.StreamClientInterceptor(printRequestID)),
+	}, opts...)
+	if cfg.Tracing.Enabled {
+		opts = append(opts, tracing.DialOption())
+	}
+
+	conn, err := grpc.Dial(address, opts...)
+	if err != nil {
+		stopTracing(context.Background())
+		return nil, err
+	}
+
+	return &Conn{ClientConn: conn, stopTracing: stopTracing}, nil
+}
+
+// Close closes the connection and flushes pending spans
+func (c *Conn) Close() error {
+	err := c.ClientConn.Close()
+	if stopErr := c.stopTracing(context.Background()); err == nil {
+		err = stopErr
+	}
+
+	return err
+}
+
+// printRequestID shows the ID to quote when looking a call up in the server logs
+func printRequestID(method, id string) {
+	log.Printf("%s request ID: %s", method, id)
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/serhii12/grpc-go/internal/grpctest"
+	"github.com/serhii12/grpc-go/requestid"
+	"google.golang.org/grpc"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestDialPrintsRequestIDs(t *testing.T) {
+	s := grpctest.NewServer(t,
+		func(s *grpc.Server) { testpb.RegisterTestServiceServer(s, grpctest.TestService{}) },
+		grpc.ChainUnaryInterceptor(requestid.UnaryServerInterceptor()),
+	)
+
+	var logged bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&logged)
+
+	conn, err := Dial("test-client", "bufnet", Config{}, s.DialOption())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer conn.Close()
+
+	var header metadata.MD
+	if _, err := testpb.NewTestServiceClient(conn.ClientConn).UnaryCall(context.Background(), &testpb.SimpleRequest{}, grpc.Header(&header)); err != nil {
+		t.Fatalf("UnaryCall: %v", err)
+	}
+
+	want := testpb.TestService_UnaryCall_FullMethodName + " request ID: " + header.Get(requestid.MetadataKey)[0]
+	if !strings.Contains(logged.String(), want) {
+		t.Errorf("logged %q, want %q", logged.String(), want)
+	}
+}
@@ -0,0 +1,562 @@
+// Package config loads server settings from a YAML or JSON file, environment
+// variables and command-line flags.
+//
+// Settings are plain structs whose leaf fields are named by their yaml tags.
+// A field at server.address is read from the file as
+//
+//	server:
+//	  address: ":50051"
+//
+// from the environment as <PREFIX>_SERVER_ADDRESS and from the command line
+// as -server.address. Flags win over environment variables, which win over
+// the file, which wins over the defaults already present in the struct.
+package config
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Validator is implemented by configs that can check their own values
+type Validator interface {
+	Validate() error
+}
+
+// Server holds the settings shared by every server
+type Server struct {
+	Address string `yaml:"address" usage:"host:port the gRPC server listens on"`
+	TLS     TLS    `yaml:"tls"`
+
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" usage:"how long in-flight RPCs may run after a shutdown signal"`
+}
+
+// TLS configures transport security for a server
+type TLS struct {
+	Enabled    bool   `yaml:"enabled" usage:"serve over TLS"`
+	CertFile   string `yaml:"cert_file" usage:"PEM certificate file"`
+	KeyFile    string `yaml:"key_file" usage:"PEM private key file"`
+	ClientCA   string `yaml:"client_ca" usage:"PEM CA bundle used to verify client certificates"`
+	ClientAuth bool   `yaml:"client_auth" usage:"require and verify client certificates (mutual TLS)"`
+
+	ReloadInterval time.Duration `yaml:"reload_interval" usage:"how often the certificate files are checked for changes, 0 disables reloading"`
+}
+
+// DefaultServer returns the server settings used when nothing overrides them
+func DefaultServer() Server {
+	return Server{
+		Address:         ":50051",
+		ShutdownTimeout: 30 * time.Second,
+		TLS: TLS{
+			CertFile:       "ssl/server.crt",
+			KeyFile:        "ssl/server.pem",
+			ClientCA:       "ssl/ca.crt",
+			ReloadInterval: 30 * time.Second,
+		},
+	}
+}
+
+// Validate checks that the listen address is a valid host:port
+// and that TLS has the files it needs
+func (s Server) Validate() error {
+	if _, _, err := net.SplitHostPort(s.Address); err != nil {
+		return fmt.Errorf("server.address: %v", err)
+	}
+
+	if s.ShutdownTimeout <= 0 {
+		return fmt.Errorf("server.shutdown_timeout: must be positive")
+	}
+
+	if s.TLS.Enabled {
+		if s.TLS.CertFile == "" || s.TLS.KeyFile == "" {
+			return fmt.Errorf("server.tls: cert_file and key_file are required when TLS is enabled")
+		}
+		if s.TLS.ClientAuth && s.TLS.ClientCA == "" {
+			return fmt.Errorf("server.tls: client_ca is required when client_auth is enabled")
+		}
+		if s.TLS.ReloadInterval < 0 {
+			return fmt.Errorf("server.tls: reload_interval must not be negative")
+		}
+	} else if s.TLS.ClientAuth {
+		return fmt.Errorf("server.tls: client_auth requires TLS to be enabled")
+	}
+
+	return nil
+}
+
+// Log configures the structured request logs
+type Log struct {
+	Level    string   `yaml:"level" usage:"minimum log level: debug, info, warn or error"`
+	Payloads bool     `yaml:"payloads" usage:"log request and response messages at debug level"`
+	Redact   []string `yaml:"redact" usage:"comma separated message fields logged as [REDACTED]"`
+}
+
+// DefaultLog returns the log settings used when nothing overrides them
+func DefaultLog() Log {
+	return Log{Level: "info"}
+}
+
+// Validate checks that the level is one slog understands
+func (l Log) Validate() error {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(l.Level)); err != nil {
+		return fmt.Errorf("log.level: %v", err)
+	}
+
+	return nil
+}
+
+// Metrics configures the Prometheus endpoint, served on its own HTTP listener
+type Metrics struct {
+	Enabled bool   `yaml:"enabled" usage:"serve Prometheus metrics over HTTP"`
+	Address string `yaml:"address" usage:"host:port the metrics endpoint listens on"`
+	Path    string `yaml:"path" usage:"URL path of the metrics endpoint"`
+}
+
+// DefaultMetrics returns the metrics settings used when nothing overrides
+// them. The endpoint is off, as servers sharing a host would all claim the
+// same address.
+func DefaultMetrics() Metrics {
+	return Metrics{Address: ":9090", Path: "/metrics"}
+}
+
+// Validate checks the endpoint address and path when metrics are enabled
+func (m Metrics) Validate() error {
+	if !m.Enabled {
+		return nil
+	}
+
+	if _, _, err := net.SplitHostPort(m.Address); err != nil {
+		return fmt.Errorf("metrics.address: %v", err)
+	}
+	if !strings.HasPrefix(m.Path, "/") {
+		return fmt.Errorf("metrics.path: must start with /")
+	}
+
+	return nil
+}
+
+// Tracing configures OpenTelemetry span export
+type Tracing struct {
+	Enabled     bool    `yaml:"enabled" usage:"record and export OpenTelemetry spans"`
+	Exporter    string  `yaml:"exporter" usage:"where spans go: stdout or otlp"`
+	Endpoint    string  `yaml:"endpoint" usage:"host:port of the OTLP gRPC collector"`
+	Insecure    bool    `yaml:"insecure" usage:"connect to the OTLP collector without TLS"`
+	SampleRatio float64 `yaml:"sample_ratio" usage:"fraction of new traces that are sampled, between 0 and 1"`
+}
+
+// DefaultTracing returns the tracing settings used when nothing overrides them
+func DefaultTracing() Tracing {
+	return Tracing{
+		Exporter:    "stdout",
+		Endpoint:    "localhost:4317",
+		Insecure:    true,
+		SampleRatio: 1,
+	}
+}
+
+// Validate checks the exporter and sample ratio when tracing is enabled
+func (t Tracing) Validate() error {
+	if !t.Enabled {
+		return nil
+	}
+
+	switch t.Exporter {
+	case "stdout":
+	case "otlp":
+		if t.Endpoint == "" {
+			return fmt.Errorf("tracing.endpoint: required by the otlp exporter")
+		}
+	default:
+		return fmt.Errorf("tracing.exporter: unknown exporter %q, want stdout or otlp", t.Exporter)
+	}
+
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		return fmt.Errorf("tracing.sample_ratio: must be between 0 and 1")
+	}
+
+	return nil
+}
+
+// RateLimit configures per-client quotas. A client is identified by its
+// certificate principal, or by its IP address when it has none.
+type RateLimit struct {
+	Enabled    bool    `yaml:"enabled" usage:"limit how fast each client may call each method"`
+	Rate       float64 `yaml:"rate" usage:"RPCs per second a client may start on one method"`
+	Burst      int     `yaml:"burst" usage:"RPCs a client may start at once on one method"`
+	MaxStreams int     `yaml:"max_streams" usage:"concurrent streams per client, 0 for no limit"`
+
+	// Methods overrides Rate and Burst for full method names
+	// such as /calculator.CalculatorService/PrimeNumberDecomposition
+	Methods map[string]MethodLimit `yaml:"methods"`
+}
+
+// MethodLimit is the token bucket of one method
+type MethodLimit struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+// DefaultRateLimit returns the rate limit settings used when nothing overrides them
+func DefaultRateLimit() RateLimit {
+	return RateLimit{Enabled: true, Rate: 50, Burst: 100, MaxStreams: 20}
+}
+
+// Validate checks that every bucket can refill and hold at least one token
+func (r RateLimit) Validate() error {
+	if !r.Enabled {
+		return nil
+	}
+
+	if r.Rate <= 0 || r.Burst < 1 {
+		return fmt.Errorf("rate_limit: rate must be positive and burst at least 1")
+	}
+	if r.MaxStreams < 0 {
+		return fmt.Errorf("rate_limit.max_streams: must not be negative")
+	}
+
+	for method, l := range r.Methods {
+		if !strings.HasPrefix(method, "/") || strings.Count(method, "/") != 2 {
+			return fmt.Errorf("rate_limit.methods: %q is not a full method name like /package.Service/Method", method)
+		}
+		if l.Rate <= 0 || l.Burst < 1 {
+			return fmt.Errorf("rate_limit.methods: %s: rate must be positive and burst at least 1", method)
+		}
+	}
+
+	return nil
+}
+
+// Deadlines bounds how long RPCs may run. A method listed in Methods uses
+// its own limits instead of Default and Max.
+type Deadlines struct {
+	Default time.Duration `yaml:"default" usage:"deadline given to RPCs that arrive without one, 0 for none"`
+	Max     time.Duration `yaml:"max" usage:"longest deadline an RPC may have, 0 for no limit"`
+
+	Methods map[string]MethodDeadline `yaml:"methods"`
+}
+
+// MethodDeadline is the default and maximum deadline of one method
+type MethodDeadline struct {
+	Default time.Duration `yaml:"default"`
+	Max     time.Duration `yaml:"max"`
+}
+
+// DefaultDeadlines returns the deadline settings used when nothing overrides them
+func DefaultDeadlines() Deadlines {
+	return Deadlines{Default: 30 * time.Second, Max: 5 * time.Minute}
+}
+
+// For returns the deadlines of the full method name
+func (d Deadlines) For(method string) MethodDeadline {
+	if m, ok := d.Methods[method]; ok {
+		return m
+	}
+
+	return MethodDeadline{Default: d.Default, Max: d.Max}
+}
+
+// Validate checks that no deadline is negative and no default exceeds its max
+func (d Deadlines) Validate() error {
+	if err := validateDeadline("deadlines", MethodDeadline{Default: d.Default, Max: d.Max}); err != nil {
+		return err
+	}
+
+	for method, m := range d.Methods {
+		if !strings.HasPrefix(method, "/") || strings.Count(method, "/") != 2 {
+			return fmt.Errorf("deadlines.methods: %q is not a full method name like /package.Service/Method", method)
+		}
+		if err := validateDeadline("deadlines.methods: "+method, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateDeadline(name string, m MethodDeadline) error {
+	if m.Default < 0 || m.Max < 0 {
+		return fmt.Errorf("%s: deadlines must not be negative", name)
+	}
+	if m.Max > 0 && m.Default > m.Max {
+		return fmt.Errorf("%s: default must not exceed max", name)
+	}
+
+	return nil
+}
+
+// flagValue holds a flag's raw value until the file and environment are applied
+type flagValue struct {
+	value  string
+	isBool bool
+}
+
+func (f *flagValue) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.value
+}
+
+func (f *flagValue) Set(s string) error {
+	f.value = s
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool { return f.isBool }
+
+// field is a single settable leaf of a config struct
+type field struct {
+	key   string
+	usage string
+	value reflect.Value
+}
+
+// Load fills cfg, a pointer to a struct holding its defaults, from the
+// config file, the environment and args. The file is taken from the -config
+// flag or the <PREFIX>_CONFIG variable; .yaml, .yml and .json are accepted.
+// The result is validated when cfg implements Validator.
+func Load(prefix string, cfg interface{}, args []string) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: expected a pointer to a struct, got %T", cfg)
+	}
+
+	prefix = strings.ToUpper(prefix)
+	fields := collect(v.Elem(), "")
+
+	fs := flag.NewFlagSet(prefix, flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv(prefix+"_CONFIG"), "path to a YAML or JSON config file")
+	flagValues := map[string]*flagValue{}
+	for _, f := range fields {
+		fv := &flagValue{value: str(f.value), isBool: f.value.Kind() == reflect.Bool}
+		flagValues[f.key] = fv
+		fs.Var(fv, f.key, fmt.Sprintf("%s (env %s)", f.usage, envName(prefix, f.key)))
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *configFile != "" {
+		if err := loadFile(*configFile, cfg); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range fields {
+		if s, ok := os.LookupEnv(envName(prefix, f.key)); ok {
+			if err := set(f.value, s); err != nil {
+				return fmt.Errorf("config: %s: %v", envName(prefix, f.key), err)
+			}
+		}
+	}
+
+	explicit := map[string]bool{}
+	fs.Visit(func(fl *flag.Flag) { explicit[fl.Name] = true })
+	for _, f := range fields {
+		if explicit[f.key] {
+			if err := set(f.value, flagValues[f.key].value); err != nil {
+				return fmt.Errorf("config: -%s: %v", f.key, err)
+			}
+		}
+	}
+
+	if val, ok := cfg.(Validator); ok {
+		if err := val.Validate(); err != nil {
+			return fmt.Errorf("config: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func loadFile(path string, cfg interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: %v", err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml", ".json":
+		// JSON is a subset of YAML, so one decoder handles both
+		if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+			return fmt.Errorf("config: parse %s: %v", path, err)
+		}
+	default:
+		return fmt.Errorf("config: unsupported file type %q", ext)
+	}
+
+	return nil
+}
+
+func envName(prefix, key string) string {
+	return prefix + "_" + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// collect walks the struct and returns its settable leaf fields.
+// Maps, slices of structs and untagged fields can only be set from the file.
+func collect(v reflect.Value, prefix string) []field {
+	var fields []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := strings.Split(sf.Tag.Get("yaml"), ",")[0]
+		if name == "" || name == "-" || sf.PkgPath != "" {
+			continue
+		}
+
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+
+		fv := v.Field(i)
+		switch {
+		case fv.Kind() == reflect.Struct:
+			fields = append(fields, collect(fv, key)...)
+		case settable(fv):
+			fields = append(fields, field{key: key, usage: sf.Tag.Get("usage"), value: fv})
+		}
+	}
+
+	return fields
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func settable(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return v.Type().Elem().Kind() == reflect.String
+	}
+
+	return false
+}
+
+// set parses s into v according to v's type
+func set(v reflect.Value, s string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+
+	return nil
+}
+
+// str renders v the way set parses it
+func str(v reflect.Value) string {
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+
+	if v.Kind() == reflect.Slice {
+		return strings.Join(v.Interface().([]string), ",")
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+// Format renders cfg as YAML for printing at startup.
+// Fields tagged secret:"true" are masked; URLs only have their password masked.
+func Format(cfg interface{}) string {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	masked := reflect.New(v.Type()).Elem()
+	masked.Set(v)
+	if masked.Kind() == reflect.Struct {
+		mask(masked)
+	}
+
+	out, err := yaml.Marshal(masked.Interface())
+	if err != nil {
+		return fmt.Sprintf("<unprintable config: %v>", err)
+	}
+
+	return string(out)
+}
+
+func mask(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		fv := v.Field(i)
+		switch {
+		case fv.Kind() == reflect.Struct:
+			mask(fv)
+		case fv.Kind() == reflect.String && sf.Tag.Get("secret") == "true" && fv.String() != "":
+			fv.SetString(maskSecret(fv.String()))
+		}
+	}
+}
+
+func maskSecret(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "****"
+	}
+
+	return u.Redacted()
+}
@@ -0,0 +1,87 @@
+// Package deadline gives RPCs that arrive without a deadline a default one
+// and shortens deadlines beyond the configured maximum, so no call can tie
+// up the server or its database indefinitely.
+package deadline
+
+import (
+	"context"
+	"time"
+
+	"github.com/serhii12/grpc-go/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Enforcer applies the configured deadlines to every RPC
+type Enforcer struct {
+	cfg config.Deadlines
+}
+
+// New returns an Enforcer for cfg
+func New(cfg config.Deadlines) *Enforcer {
+	return &Enforcer{cfg: cfg}
+}
+
+// UnaryServerInterceptor bounds the context of unary RPCs
+func (e *Enforcer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := e.bound(ctx, info.FullMethod)
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor bounds the context of streaming RPCs. A handler
+// blocked in RecvMsg or SendMsg does not see its context end, so the
+// stream is ended with the context's status when the bounded deadline
+// passes, whether or not the handler has returned.
+func (e *Enforcer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := e.bound(ss.Context(), info.FullMethod)
+		defer cancel()
+
+		// gRPC itself ends the stream when the client's deadline passes
+		if ctx == ss.Context() {
+			return handler(srv, ss)
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			done <- handler(srv, &stream{ServerStream: ss, ctx: ctx})
+		}()
+
+		select {
+		case err := <-done:
+			return err
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+// bound returns ctx with the method's default deadline when it has none,
+// or with its max deadline when the client asked for more
+func (e *Enforcer) bound(ctx context.Context, method string) (context.Context, context.CancelFunc) {
+	limit := e.cfg.For(method)
+	deadline, ok := ctx.Deadline()
+
+	switch {
+	case !ok && limit.Default > 0:
+		return context.WithTimeout(ctx, limit.Default)
+	case limit.Max > 0 && (!ok || time.Until(deadline) > limit.Max):
+		return context.WithTimeout(ctx, limit.Max)
+	}
+
+	return ctx, func() {}
+}
+
+// stream overrides the context of a server stream
+type stream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *stream) Context() context.Context {
+	return s.ctx
+}
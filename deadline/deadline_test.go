@@ -0,0 +1,156 @@
+package deadline
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/serhii12/grpc-go/config"
+	"github.com/serhii12/grpc-go/internal/grpctest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/status"
+)
+
+// noDeadline is reported by deadlineService for contexts without a deadline
+const noDeadline time.Duration = -1
+
+// deadlineService reports the time left to the deadline its handlers see
+type deadlineService struct {
+	grpctest.TestService
+	left chan time.Duration
+}
+
+func (s deadlineService) report(ctx context.Context) {
+	if deadline, ok := ctx.Deadline(); ok {
+		s.left <- time.Until(deadline)
+		return
+	}
+	s.left <- noDeadline
+}
+
+func (s deadlineService) UnaryCall(ctx context.Context, req *testpb.SimpleRequest) (*testpb.SimpleResponse, error) {
+	s.report(ctx)
+	return s.TestService.UnaryCall(ctx, req)
+}
+
+func (s deadlineService) StreamingOutputCall(req *testpb.StreamingOutputCallRequest, stream testpb.TestService_StreamingOutputCallServer) error {
+	s.report(stream.Context())
+	return s.TestService.StreamingOutputCall(req, stream)
+}
+
+// FullDuplexCall waits for the client to close its side, which is only
+// ever left by the stream ending
+func (s deadlineService) FullDuplexCall(stream testpb.TestService_FullDuplexCallServer) error {
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return err
+		}
+	}
+}
+
+func startServer(t *testing.T, cfg config.Deadlines) (testpb.TestServiceClient, *grpctest.Server, <-chan time.Duration) {
+	t.Helper()
+	left := make(chan time.Duration, 1)
+	e := New(cfg)
+	s := grpctest.NewServer(t,
+		func(s *grpc.Server) { testpb.RegisterTestServiceServer(s, deadlineService{left: left}) },
+		grpc.ChainUnaryInterceptor(e.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(e.StreamServerInterceptor()),
+	)
+	return testpb.NewTestServiceClient(s.Conn), s, left
+}
+
+func TestDeadlines(t *testing.T) {
+	cfg := config.Deadlines{
+		Default: time.Minute,
+		Max:     5 * time.Minute,
+		Methods: map[string]config.MethodDeadline{
+			testpb.TestService_StreamingOutputCall_FullMethodName: {Default: 2 * time.Minute, Max: 10 * time.Minute},
+		},
+	}
+	c, _, left := startServer(t, cfg)
+
+	unary := func(ctx context.Context) error {
+		_, err := c.UnaryCall(ctx, &testpb.SimpleRequest{})
+		return err
+	}
+	stream := func(ctx context.Context) error {
+		stream, err := c.StreamingOutputCall(ctx, &testpb.StreamingOutputCallRequest{})
+		if err != nil {
+			return err
+		}
+		if _, err := stream.Recv(); err != io.EOF {
+			return err
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name    string
+		call    func(ctx context.Context) error
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{name: "unary without a deadline gets the default", call: unary, want: time.Minute},
+		{name: "unary deadline beyond max is clamped", call: unary, timeout: time.Hour, want: 5 * time.Minute},
+		{name: "unary deadline within max is kept", call: unary, timeout: 10 * time.Second, want: 10 * time.Second},
+		{name: "stream without a deadline gets the method default", call: stream, want: 2 * time.Minute},
+		{name: "stream deadline beyond the method max is clamped", call: stream, timeout: time.Hour, want: 10 * time.Minute},
+		{name: "stream deadline within the method max is kept", call: stream, timeout: 7 * time.Minute, want: 7 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			if err := tt.call(ctx); err != nil {
+				t.Fatalf("call: %v", err)
+			}
+
+			got := <-left
+			if got > tt.want || got < tt.want-5*time.Second {
+				t.Errorf("handler had %v left, want about %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoLimits(t *testing.T) {
+	c, _, left := startServer(t, config.Deadlines{})
+
+	if _, err := c.UnaryCall(context.Background(), &testpb.SimpleRequest{}); err != nil {
+		t.Fatalf("UnaryCall: %v", err)
+	}
+	if got := <-left; got != noDeadline {
+		t.Errorf("handler had %v left, want no deadline", got)
+	}
+}
+
+func TestStreamEndsAtTheEnforcedDeadline(t *testing.T) {
+	c, s, _ := startServer(t, config.Deadlines{Default: 200 * time.Millisecond, Max: time.Second})
+
+	stream, err := c.FullDuplexCall(context.Background())
+	if err != nil {
+		t.Fatalf("FullDuplexCall: %v", err)
+	}
+
+	start := time.Now()
+	if _, err := stream.Recv(); status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("Recv: got %v, want DeadlineExceeded", err)
+	}
+	if took := time.Since(start); took > 2*time.Second {
+		t.Errorf("stream ended after %v, want about 200ms", took)
+	}
+
+	if h := s.Wait(t, testpb.TestService_FullDuplexCall_FullMethodName); h.Code != codes.DeadlineExceeded {
+		t.Errorf("interceptor returned %v, want DeadlineExceeded", h.Code)
+	}
+}
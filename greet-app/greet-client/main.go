@@ -2,19 +2,29 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
 	"time"
 
+	"github.com/serhii12/grpc-go/client"
 	pb "github.com/serhii12/grpc-go/greet-app/greetpb"
-	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	address = "localhost:50051"
 )
 
+var (
+	mode    = flag.String("mode", "bidi", "call to make: unary, server-stream, client-stream, bidi or deadline")
+	timeout = flag.Duration("timeout", 5*time.Second, "deadline of the call in deadline mode; the server needs about 3s")
+
+	clientConfig client.Config
+)
+
 func doUnaryAPI(cxt context.Context, c pb.GreetServiceClient) {
 	fmt.Println("Testing grpc Unary")
 
@@ -184,22 +194,57 @@ func doBiStream(cxt context.Context, c pb.GreetServiceClient) {
 	<-waitc
 }
 
+func doUnaryWithDeadline(cxt context.Context, c pb.GreetServiceClient, timeout time.Duration) {
+	fmt.Printf("Testing grpc Unary with a %v deadline\n", timeout)
+
+	req := &pb.GreetWithDeadlineRequest{
+		Greeting: &pb.Greeting{
+			FirstName: "Alex",
+			LastName:  "Brown",
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(cxt, timeout)
+	defer cancel()
+
+	resp, err := c.GreetWithDeadline(ctx, req)
+	if err != nil {
+		if status.Code(err) == codes.DeadlineExceeded {
+			fmt.Println("Timeout was hit! Deadline was exceeded")
+			return
+		}
+		log.Fatalf("could not greet: %v", err)
+	}
+
+	log.Printf("Response from GreetWithDeadline: %v", resp.GetResult())
+}
+
 func main() {
+	clientConfig.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
 	// Set up a connection to the server.
-	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock())
+	conn, err := client.Dial("greet-client", address, clientConfig)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
 	defer conn.Close()
 
-	c := pb.NewGreetServiceClient(conn)
+	c := pb.NewGreetServiceClient(conn.ClientConn)
 
 	ctx := context.Background()
-	// doUnaryAPI(ctx, c)
-
-	// doServerStream(ctx, c)
-
-	// doClientStream(ctx, c)
-
-	doBiStream(ctx, c)
+	switch *mode {
+	case "unary":
+		doUnaryAPI(ctx, c)
+	case "server-stream":
+		doServerStream(ctx, c)
+	case "client-stream":
+		doClientStream(ctx, c)
+	case "bidi":
+		doBiStream(ctx, c)
+	case "deadline":
+		doUnaryWithDeadline(ctx, c, *timeout)
+	default:
+		log.Fatalf("unknown mode %q", *mode)
+	}
 }
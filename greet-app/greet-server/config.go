@@ -0,0 +1,48 @@
+package main
+
+import "github.com/serhii12/grpc-go/config"
+
+// serverConfig is the greet-server configuration, see package config
+type serverConfig struct {
+	Server    config.Server    `yaml:"server"`
+	Log       config.Log       `yaml:"log"`
+	Metrics   config.Metrics   `yaml:"metrics"`
+	Tracing   config.Tracing   `yaml:"tracing"`
+	RateLimit config.RateLimit `yaml:"rate_limit"`
+	Deadlines config.Deadlines `yaml:"deadlines"`
+}
+
+func defaultConfig() *serverConfig {
+	return &serverConfig{
+		Server:    config.DefaultServer(),
+		Log:       config.DefaultLog(),
+		Metrics:   config.DefaultMetrics(),
+		Tracing:   config.DefaultTracing(),
+		RateLimit: config.DefaultRateLimit(),
+		Deadlines: config.DefaultDeadlines(),
+	}
+}
+
+func (c *serverConfig) Validate() error {
+	if err := c.Server.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Log.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Metrics.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Tracing.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.RateLimit.Validate(); err != nil {
+		return err
+	}
+
+	return c.Deadlines.Validate()
+}
@@ -2,19 +2,34 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net"
+	"os"
 	"strconv"
 	"time"
 
+	"github.com/serhii12/grpc-go/certs"
+	"github.com/serhii12/grpc-go/config"
+	"github.com/serhii12/grpc-go/deadline"
 	pb "github.com/serhii12/grpc-go/greet-app/greetpb"
+	"github.com/serhii12/grpc-go/logging"
+	"github.com/serhii12/grpc-go/metrics"
+	"github.com/serhii12/grpc-go/ratelimit"
+	"github.com/serhii12/grpc-go/recovery"
+	"github.com/serhii12/grpc-go/requestid"
+	"github.com/serhii12/grpc-go/shutdown"
+	"github.com/serhii12/grpc-go/streamerr"
+	"github.com/serhii12/grpc-go/tracing"
 	"google.golang.org/grpc"
-)
-
-const (
-	port = ":50051"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 )
 
 // server is used to implement GreetServiceServer
@@ -23,8 +38,6 @@ type server struct {
 }
 
 func (s *server) Greet(ctx context.Context, in *pb.GreetRequest) (*pb.GreetResponse, error) {
-	fmt.Println("Calling greet func")
-
 	fN := in.GetGreeting().GetFirstName()
 
 	result := "Hello " + fN
@@ -36,10 +49,33 @@ func (s *server) Greet(ctx context.Context, in *pb.GreetRequest) (*pb.GreetRespo
 	return resp, nil
 }
 
-func (s *server) GreetManyTimes(in *pb.GreetManyTimesRequest, stream pb.GreetService_GreetManyTimesServer) error {
-	fmt.Printf("GreetManyTimes function was invoked with %v\n", in)
+// GreetWithDeadline greets after about three seconds of simulated work,
+// giving up as soon as the client cancels or its deadline passes
+func (s *server) GreetWithDeadline(ctx context.Context, in *pb.GreetWithDeadlineRequest) (*pb.GreetWithDeadlineResponse, error) {
+	for i := 0; i < 3; i++ {
+		select {
+		case <-ctx.Done():
+		case <-time.After(1000 * time.Millisecond):
+		}
+
+		switch ctx.Err() {
+		case context.Canceled:
+			return nil, status.Error(codes.Canceled, "The client canceled the request")
+		case context.DeadlineExceeded:
+			return nil, status.Error(codes.DeadlineExceeded, "The deadline passed before the greeting was ready")
+		}
+	}
+
+	fN := in.GetGreeting().GetFirstName()
 
+	return &pb.GreetWithDeadlineResponse{
+		Result: "Hello " + fN,
+	}, nil
+}
+
+func (s *server) GreetManyTimes(in *pb.GreetManyTimesRequest, stream pb.GreetService_GreetManyTimesServer) error {
 	firstName := in.GetGreeting().GetFirstName()
+	ctx := stream.Context()
 
 	for i := 0; i < 10; i++ {
 		result := "Hello " + firstName + " number " + strconv.Itoa(i)
@@ -48,16 +84,21 @@ func (s *server) GreetManyTimes(in *pb.GreetManyTimesRequest, stream pb.GreetSer
 			Result: result,
 		}
 
-		stream.Send(resp)
-		time.Sleep(1000 * time.Millisecond)
+		if err := stream.Send(resp); err != nil {
+			return streamerr.Status(err, "send data to the client")
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-time.After(1000 * time.Millisecond):
+		}
 	}
 
 	return nil
 }
 
 func (s *server) LongGreet(stream pb.GreetService_LongGreetServer) error {
-	fmt.Printf("LongGreet function was invoked with %v\n", stream)
-
 	var result string
 	for {
 		req, err := stream.Recv()
@@ -68,7 +109,7 @@ func (s *server) LongGreet(stream pb.GreetService_LongGreetServer) error {
 			})
 		}
 		if err != nil {
-			log.Fatalf("Error reading file stream: %v", err)
+			return streamerr.Status(err, "read the client stream")
 		}
 
 		firstName := req.GetGreeting().GetFirstName()
@@ -77,8 +118,6 @@ func (s *server) LongGreet(stream pb.GreetService_LongGreetServer) error {
 }
 
 func (s *server) GreetEveryone(stream pb.GreetService_GreetEveryoneServer) error {
-	fmt.Printf("GreetEveryone function was invoked with %v\n", stream)
-
 	var result string
 	for {
 		req, err := stream.Recv()
@@ -87,8 +126,7 @@ func (s *server) GreetEveryone(stream pb.GreetService_GreetEveryoneServer) error
 			return nil
 		}
 		if err != nil {
-			log.Fatalf("Error reading file stream: %v", err)
-			return err
+			return streamerr.Status(err, "read the client stream")
 		}
 
 		firstName := req.GetGreeting().GetFirstName()
@@ -97,26 +135,99 @@ func (s *server) GreetEveryone(stream pb.GreetService_GreetEveryoneServer) error
 		if err := stream.Send(&pb.GreetEveryoneResponse{
 			Result: result,
 		}); err != nil {
-			log.Fatalf("Failed to send data to client: %v", err)
-			return err
+			return streamerr.Status(err, "send data to the client")
 		}
 	}
-
 }
 
 func main() {
-	fmt.Println("Greet Server")
+	cfg := defaultConfig()
+	if err := config.Load("greet", cfg, os.Args[1:]); err != nil {
+		// -h already printed the usage
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		log.Fatal(err)
+	}
+	fmt.Printf("Effective config:\n%s", config.Format(cfg))
+
+	logger, err := logging.New(cfg.Log)
+	if err != nil {
+		log.Fatal(err)
+	}
+	slog.SetDefault(logger)
+
+	serverMetrics := metrics.New()
+	metricsServer, err := serverMetrics.Serve(cfg.Metrics)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	stopTracing, err := tracing.Setup(context.Background(), "greet-server", cfg.Tracing)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	lis, err := net.Listen("tcp", port)
+	lis, err := net.Listen("tcp", cfg.Server.Address)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	s := grpc.NewServer()
+	opts, err := certs.ServerOptions(cfg.Server.TLS)
+	if err != nil {
+		log.Fatalf("failed to load TLS credentials: %v", err)
+	}
+	limiter := ratelimit.New(cfg.RateLimit)
+	deadlines := deadline.New(cfg.Deadlines)
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(
+			requestid.UnaryServerInterceptor(),
+			logging.UnaryServerInterceptor(logger, cfg.Log),
+			serverMetrics.UnaryServerInterceptor(),
+			limiter.UnaryServerInterceptor(),
+			deadlines.UnaryServerInterceptor(),
+			recovery.UnaryServerInterceptor(serverMetrics),
+		),
+		grpc.ChainStreamInterceptor(
+			requestid.StreamServerInterceptor(),
+			logging.StreamServerInterceptor(logger, cfg.Log),
+			serverMetrics.StreamServerInterceptor(),
+			limiter.StreamServerInterceptor(),
+			deadlines.StreamServerInterceptor(),
+			recovery.StreamServerInterceptor(serverMetrics),
+		),
+	)
+
+	if cfg.Tracing.Enabled {
+		opts = append(opts, tracing.ServerOption())
+	}
+
+	s := grpc.NewServer(opts...)
 
 	pb.RegisterGreetServiceServer(s, &server{})
 
-	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("greet.GreetService", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s, healthServer)
+
+	go func() {
+		slog.Info("Greet Server started", "address", lis.Addr().String())
+
+		if err := s.Serve(lis); err != nil {
+			log.Fatalf("failed to serve: %v", err)
+		}
+	}()
+
+	// Block until Control C or SIGTERM is received
+	sig := shutdown.Wait()
+	slog.Info("Stopping the server", "signal", sig.String())
+	shutdown.Graceful(s, healthServer, cfg.Server.ShutdownTimeout)
+	if metricsServer != nil {
+		metricsServer.Close()
+	}
+	slog.Info("Server stopped")
+
+	if err := stopTracing(context.Background()); err != nil {
+		slog.Error("Failed to flush spans", "error", err)
 	}
 }
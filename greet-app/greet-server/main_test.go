@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/serhii12/grpc-go/greet-app/greetpb"
+	"github.com/serhii12/grpc-go/internal/grpctest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// startServer serves the greet service over an in-memory connection
+func startServer(t *testing.T) (pb.GreetServiceClient, *grpctest.Server) {
+	t.Helper()
+	s := grpctest.NewServer(t, func(s *grpc.Server) {
+		pb.RegisterGreetServiceServer(s, &server{})
+	})
+	return pb.NewGreetServiceClient(s.Conn), s
+}
+
+func greeting(name string) *pb.Greeting {
+	return &pb.Greeting{FirstName: name}
+}
+
+func TestStreamsSurviveClientDisconnect(t *testing.T) {
+	c, s := startServer(t)
+
+	tests := []struct {
+		name   string
+		method string
+		// start opens the stream and uses it partway before returning
+		start func(ctx context.Context) error
+	}{
+		{
+			name:   "server stream",
+			method: "/greet.GreetService/GreetManyTimes",
+			start: func(ctx context.Context) error {
+				stream, err := c.GreetManyTimes(ctx, &pb.GreetManyTimesRequest{Greeting: greeting("Ann")})
+				if err != nil {
+					return err
+				}
+				_, err = stream.Recv()
+				return err
+			},
+		},
+		{
+			name:   "client stream",
+			method: "/greet.GreetService/LongGreet",
+			start: func(ctx context.Context) error {
+				stream, err := c.LongGreet(ctx)
+				if err != nil {
+					return err
+				}
+				for _, name := range []string{"Ann", "Bob"} {
+					if err := stream.Send(&pb.LongGreetRequest{Greeting: greeting(name)}); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		},
+		{
+			name:   "bidi stream",
+			method: "/greet.GreetService/GreetEveryone",
+			start: func(ctx context.Context) error {
+				stream, err := c.GreetEveryone(ctx)
+				if err != nil {
+					return err
+				}
+				if err := stream.Send(&pb.GreetEveryoneRequest{Greeting: greeting("Ann")}); err != nil {
+					return err
+				}
+				_, err = stream.Recv()
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			if err := tt.start(ctx); err != nil {
+				t.Fatalf("starting the stream: %v", err)
+			}
+			cancel()
+
+			if h := s.Wait(t, tt.method); h.Code != codes.Canceled {
+				t.Errorf("handler returned %v, want Canceled", h.Code)
+			}
+
+			resp, err := c.Greet(context.Background(), &pb.GreetRequest{Greeting: greeting("Eve")})
+			if err != nil {
+				t.Fatalf("Greet after the disconnect: %v", err)
+			}
+			if resp.GetResult() != "Hello Eve" {
+				t.Errorf("Greet = %q", resp.GetResult())
+			}
+		})
+	}
+}
+
+func TestGreetWithDeadline(t *testing.T) {
+	c, s := startServer(t)
+	const method = "/greet.GreetService/GreetWithDeadline"
+
+	tests := []struct {
+		name     string
+		timeout  time.Duration
+		cancelAt time.Duration
+		want     codes.Code
+	}{
+		{name: "in time", timeout: 10 * time.Second, want: codes.OK},
+		{name: "deadline passes", timeout: 1500 * time.Millisecond, want: codes.DeadlineExceeded},
+		{name: "client cancels", timeout: 10 * time.Second, cancelAt: 500 * time.Millisecond, want: codes.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			if tt.cancelAt > 0 {
+				time.AfterFunc(tt.cancelAt, cancel)
+			}
+
+			start := time.Now()
+			resp, err := c.GreetWithDeadline(ctx, &pb.GreetWithDeadlineRequest{Greeting: greeting("Ann")})
+			if code := status.Code(err); code != tt.want {
+				t.Fatalf("got %v (%v), want %v", code, err, tt.want)
+			}
+			if tt.want == codes.OK && resp.GetResult() != "Hello Ann" {
+				t.Errorf("result = %q", resp.GetResult())
+			}
+
+			h := s.Wait(t, method)
+			if h.Code != tt.want {
+				t.Errorf("handler returned %v, want %v", h.Code, tt.want)
+			}
+
+			// the work takes three seconds, a handler that stops on
+			// ctx.Done returns well before that
+			if tt.want != codes.OK {
+				stop := tt.timeout
+				if tt.cancelAt > 0 {
+					stop = tt.cancelAt
+				}
+				if worked := h.At.Sub(start); worked > stop+500*time.Millisecond {
+					t.Errorf("handler kept working for %v after being stopped at %v", worked, stop)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Package grpctest serves gRPC services over an in-memory connection for
+// tests and records what their handlers return.
+package grpctest
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// waitTimeout is how long Wait waits for a handler to return
+const waitTimeout = 5 * time.Second
+
+// Handled is what a handler returned, and when
+type Handled struct {
+	Method string
+	Code   codes.Code
+	Err    error
+	At     time.Time
+}
+
+// Server is a gRPC server listening on an in-memory connection. It is
+// stopped when the test ends.
+type Server struct {
+	*grpc.Server
+	// Conn is a client connection to the server
+	Conn *grpc.ClientConn
+
+	lis *bufconn.Listener
+
+	mu      sync.Mutex
+	handled []Handled
+	// changed is closed and replaced whenever a handler returns
+	changed chan struct{}
+}
+
+// NewServer starts a server with opts, registers its services with register
+// and connects to it. Every handler is recorded for Wait as it returns
+// through the interceptors in opts, which must therefore be chained with
+// grpc.ChainUnaryInterceptor and grpc.ChainStreamInterceptor.
+func NewServer(t testing.TB, register func(*grpc.Server), opts ...grpc.ServerOption) *Server {
+	t.Helper()
+	s := &Server{
+		lis:     bufconn.Listen(1 << 20),
+		changed: make(chan struct{}),
+	}
+
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			resp, err := handler(ctx, req)
+			s.record(info.FullMethod, err)
+			return resp, err
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			err := handler(srv, ss)
+			s.record(info.FullMethod, err)
+			return err
+		}),
+	}, opts...)
+	s.Server = grpc.NewServer(opts...)
+	register(s.Server)
+
+	go s.Serve(s.lis)
+	t.Cleanup(s.Stop)
+
+	s.Conn = s.Dial(t)
+
+	return s
+}
+
+// Dial opens another connection to the server, closed when the test ends
+func (s *Server) Dial(t testing.TB, opts ...grpc.DialOption) *grpc.ClientConn {
+	t.Helper()
+	opts = append([]grpc.DialOption{grpc.WithInsecure(), s.DialOption()}, opts...)
+
+	conn, err := grpc.Dial("bufnet", opts...)
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// DialOption makes grpc.Dial connect to the server, whatever the target
+func (s *Server) DialOption() grpc.DialOption {
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return s.lis.DialContext(ctx)
+	})
+}
+
+func (s *Server) record(method string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handled = append(s.handled, Handled{Method: method, Code: status.Code(err), Err: err, At: time.Now()})
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// Wait returns what the next handler of the full method name returned,
+// failing the test when none returns within five seconds
+func (s *Server) Wait(t testing.TB, method string) Handled {
+	t.Helper()
+	timeout := time.NewTimer(waitTimeout)
+	defer timeout.Stop()
+
+	for {
+		s.mu.Lock()
+		for i, h := range s.handled {
+			if h.Method == method {
+				s.handled = append(s.handled[:i], s.handled[i+1:]...)
+				s.mu.Unlock()
+				return h
+			}
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-timeout.C:
+			t.Fatalf("%s never returned", method)
+		}
+	}
+}
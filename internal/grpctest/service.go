@@ -0,0 +1,104 @@
+package grpctest
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/status"
+)
+
+// TestService is a grpc.testing.TestService for exercising interceptors
+// with every kind of RPC. Each call fails with the request's
+// response_status when it sets one.
+type TestService struct {
+	testpb.UnimplementedTestServiceServer
+}
+
+// UnaryCall echoes the request payload
+func (TestService) UnaryCall(ctx context.Context, req *testpb.SimpleRequest) (*testpb.SimpleResponse, error) {
+	if err := echoStatus(req.GetResponseStatus()); err != nil {
+		return nil, err
+	}
+
+	resp := &testpb.SimpleResponse{Payload: req.GetPayload()}
+	if req.GetFillUsername() {
+		resp.Username = "user"
+	}
+
+	return resp, nil
+}
+
+// StreamingOutputCall sends a response of the given size for every response
+// parameter, each after its interval
+func (TestService) StreamingOutputCall(req *testpb.StreamingOutputCallRequest, stream testpb.TestService_StreamingOutputCallServer) error {
+	if err := respond(stream.Context(), req.GetResponseParameters(), stream.Send); err != nil {
+		return err
+	}
+
+	return echoStatus(req.GetResponseStatus())
+}
+
+// StreamingInputCall reports the total payload size the client sent
+func (TestService) StreamingInputCall(stream testpb.TestService_StreamingInputCallServer) error {
+	var size int32
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&testpb.StreamingInputCallResponse{AggregatedPayloadSize: size})
+		}
+		if err != nil {
+			return err
+		}
+		size += int32(len(req.GetPayload().GetBody()))
+	}
+}
+
+// FullDuplexCall answers every request as StreamingOutputCall would
+func (TestService) FullDuplexCall(stream testpb.TestService_FullDuplexCallServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := echoStatus(req.GetResponseStatus()); err != nil {
+			return err
+		}
+		if err := respond(stream.Context(), req.GetResponseParameters(), stream.Send); err != nil {
+			return err
+		}
+	}
+}
+
+func echoStatus(s *testpb.EchoStatus) error {
+	if s.GetCode() == 0 {
+		return nil
+	}
+
+	return status.Error(codes.Code(s.GetCode()), s.GetMessage())
+}
+
+func respond(ctx context.Context, params []*testpb.ResponseParameters, send func(*testpb.StreamingOutputCallResponse) error) error {
+	for _, p := range params {
+		if p.GetIntervalUs() > 0 {
+			select {
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			case <-time.After(time.Duration(p.GetIntervalUs()) * time.Microsecond):
+			}
+		}
+
+		resp := &testpb.StreamingOutputCallResponse{Payload: &testpb.Payload{Body: make([]byte, p.GetSize())}}
+		if err := send(resp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
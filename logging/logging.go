@@ -0,0 +1,152 @@
+// Package logging writes one structured JSON log record per RPC.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/serhii12/grpc-go/config"
+	"github.com/serhii12/grpc-go/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// New returns a JSON logger writing records at or above cfg.Level to stderr
+func New(cfg config.Log) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, err
+	}
+
+	return slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: level})), nil
+}
+
+// UnaryServerInterceptor logs every unary RPC once it has completed
+func UnaryServerInterceptor(logger *slog.Logger, cfg config.Log) grpc.UnaryServerInterceptor {
+	r := newRedactor(cfg.Redact)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		attrs := rpcAttrs(ctx, info.FullMethod, code, time.Since(start))
+		if err != nil {
+			attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+		}
+		if cfg.Payloads && logger.Enabled(ctx, slog.LevelDebug) {
+			attrs = append(attrs, slog.Any("request", r.payload(req)))
+			if err == nil {
+				attrs = append(attrs, slog.Any("response", r.payload(resp)))
+			}
+		}
+
+		logger.LogAttrs(ctx, codeToLevel(code), "finished unary call", attrs...)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor logs every streaming RPC once it has completed,
+// including how many messages went each way
+func StreamServerInterceptor(logger *slog.Logger, cfg config.Log) grpc.StreamServerInterceptor {
+	r := newRedactor(cfg.Redact)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := ss.Context()
+		ws := &countingStream{ServerStream: ss}
+		if cfg.Payloads && logger.Enabled(ctx, slog.LevelDebug) {
+			ws.onMsg = func(direction string, m interface{}) {
+				logger.LogAttrs(ctx, slog.LevelDebug, "stream message",
+					slog.String("method", info.FullMethod),
+					slog.String("direction", direction),
+					slog.Any("message", r.payload(m)),
+				)
+			}
+		}
+
+		err := handler(srv, ws)
+
+		code := status.Code(err)
+		attrs := rpcAttrs(ctx, info.FullMethod, code, time.Since(start))
+		attrs = append(attrs,
+			slog.Int("messages_received", ws.received),
+			slog.Int("messages_sent", ws.sent),
+		)
+		if err != nil {
+			attrs = append(attrs, slog.String("error", status.Convert(err).Message()))
+		}
+
+		logger.LogAttrs(ctx, codeToLevel(code), "finished streaming call", attrs...)
+		return err
+	}
+}
+
+func rpcAttrs(ctx context.Context, method string, code codes.Code, latency time.Duration) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("code", code.String()),
+		slog.Float64("latency_ms", float64(latency)/float64(time.Millisecond)),
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		attrs = append(attrs, slog.String("peer", p.Addr.String()))
+	}
+
+	if id := requestid.FromContext(ctx); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+
+	return attrs
+}
+
+// codeToLevel logs client mistakes at info, degraded service at warn and
+// server faults at error
+func codeToLevel(code codes.Code) slog.Level {
+	switch code {
+	case codes.OK, codes.Canceled, codes.InvalidArgument, codes.NotFound,
+		codes.AlreadyExists, codes.Unauthenticated:
+		return slog.LevelInfo
+	case codes.DeadlineExceeded, codes.PermissionDenied, codes.ResourceExhausted,
+		codes.FailedPrecondition, codes.Aborted, codes.OutOfRange, codes.Unavailable:
+		return slog.LevelWarn
+	default:
+		return slog.LevelError
+	}
+}
+
+// countingStream counts the messages passing through a server stream
+type countingStream struct {
+	grpc.ServerStream
+	received int
+	sent     int
+	onMsg    func(direction string, m interface{})
+}
+
+func (s *countingStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received++
+		if s.onMsg != nil {
+			s.onMsg("received", m)
+		}
+	}
+
+	return err
+}
+
+func (s *countingStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent++
+		if s.onMsg != nil {
+			s.onMsg("sent", m)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,202 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/serhii12/grpc-go/config"
+	"github.com/serhii12/grpc-go/internal/grpctest"
+	"github.com/serhii12/grpc-go/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/metadata"
+)
+
+// syncBuffer is a bytes.Buffer handlers may write while the test reads it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// records returns the JSON log records written so far
+func (b *syncBuffer) records(t *testing.T) []map[string]interface{} {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var r map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("log line %q: %v", line, err)
+		}
+		records = append(records, r)
+	}
+
+	return records
+}
+
+// startServer serves the test service with request IDs and logging at
+// debug level into the returned buffer
+func startServer(t *testing.T, cfg config.Log) (*grpctest.Server, testpb.TestServiceClient, *syncBuffer) {
+	t.Helper()
+	buf := &syncBuffer{}
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	s := grpctest.NewServer(t,
+		func(s *grpc.Server) { testpb.RegisterTestServiceServer(s, grpctest.TestService{}) },
+		grpc.ChainUnaryInterceptor(requestid.UnaryServerInterceptor(), UnaryServerInterceptor(logger, cfg)),
+		grpc.ChainStreamInterceptor(requestid.StreamServerInterceptor(), StreamServerInterceptor(logger, cfg)),
+	)
+
+	return s, testpb.NewTestServiceClient(s.Conn), buf
+}
+
+// finished returns the single "finished" record of the log
+func finished(t *testing.T, buf *syncBuffer) map[string]interface{} {
+	t.Helper()
+	var found []map[string]interface{}
+	for _, r := range buf.records(t) {
+		if strings.HasPrefix(r["msg"].(string), "finished") {
+			found = append(found, r)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("got %d finished records, want 1: %v", len(found), found)
+	}
+
+	return found[0]
+}
+
+func checkFields(t *testing.T, r map[string]interface{}, want map[string]interface{}) {
+	t.Helper()
+	for key, value := range want {
+		if r[key] != value {
+			t.Errorf("%s = %v, want %v", key, r[key], value)
+		}
+	}
+	if latency, ok := r["latency_ms"].(float64); !ok || latency < 0 {
+		t.Errorf("latency_ms = %v, want a duration", r["latency_ms"])
+	}
+}
+
+func TestUnaryCallIsLogged(t *testing.T) {
+	s, c, buf := startServer(t, config.DefaultLog())
+	ctx := metadata.AppendToOutgoingContext(context.Background(), requestid.MetadataKey, "req-1")
+
+	if _, err := c.UnaryCall(ctx, &testpb.SimpleRequest{}); err != nil {
+		t.Fatalf("UnaryCall: %v", err)
+	}
+	s.Wait(t, testpb.TestService_UnaryCall_FullMethodName)
+
+	checkFields(t, finished(t, buf), map[string]interface{}{
+		"msg":        "finished unary call",
+		"level":      "INFO",
+		"method":     testpb.TestService_UnaryCall_FullMethodName,
+		"code":       "OK",
+		"request_id": "req-1",
+		"peer":       "bufconn",
+	})
+}
+
+func TestFailedCallIsLoggedAtItsCodesLevel(t *testing.T) {
+	tests := []struct {
+		code  codes.Code
+		level string
+	}{
+		{code: codes.NotFound, level: "INFO"},
+		{code: codes.ResourceExhausted, level: "WARN"},
+		{code: codes.Internal, level: "ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			s, c, buf := startServer(t, config.DefaultLog())
+
+			req := &testpb.SimpleRequest{ResponseStatus: &testpb.EchoStatus{Code: int32(tt.code), Message: "it failed"}}
+			c.UnaryCall(context.Background(), req)
+			s.Wait(t, testpb.TestService_UnaryCall_FullMethodName)
+
+			r := finished(t, buf)
+			checkFields(t, r, map[string]interface{}{
+				"level": tt.level,
+				"code":  tt.code.String(),
+				"error": "it failed",
+			})
+			if id, _ := r["request_id"].(string); id == "" {
+				t.Error("no generated request_id")
+			}
+		})
+	}
+}
+
+func TestStreamIsLoggedWithMessageCounts(t *testing.T) {
+	s, c, buf := startServer(t, config.DefaultLog())
+	ctx := metadata.AppendToOutgoingContext(context.Background(), requestid.MetadataKey, "req-2")
+
+	stream, err := c.FullDuplexCall(ctx)
+	if err != nil {
+		t.Fatalf("FullDuplexCall: %v", err)
+	}
+	req := &testpb.StreamingOutputCallRequest{ResponseParameters: []*testpb.ResponseParameters{{Size: 1}, {Size: 1}}}
+	for i := 0; i < 3; i++ {
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+	}
+	stream.CloseSend()
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+	}
+	s.Wait(t, testpb.TestService_FullDuplexCall_FullMethodName)
+
+	checkFields(t, finished(t, buf), map[string]interface{}{
+		"msg":               "finished streaming call",
+		"level":             "INFO",
+		"method":            testpb.TestService_FullDuplexCall_FullMethodName,
+		"code":              "OK",
+		"request_id":        "req-2",
+		"messages_received": float64(3),
+		"messages_sent":     float64(6),
+	})
+}
+
+func TestPayloadsAreRedacted(t *testing.T) {
+	cfg := config.DefaultLog()
+	cfg.Payloads = true
+	cfg.Redact = []string{"username"}
+	s, c, buf := startServer(t, cfg)
+
+	if _, err := c.UnaryCall(context.Background(), &testpb.SimpleRequest{FillUsername: true, ResponseSize: 7}); err != nil {
+		t.Fatalf("UnaryCall: %v", err)
+	}
+	s.Wait(t, testpb.TestService_UnaryCall_FullMethodName)
+
+	r := finished(t, buf)
+	if req, _ := r["request"].(map[string]interface{}); req["response_size"] != float64(7) {
+		t.Errorf("request = %v, want its response_size", r["request"])
+	}
+	if resp, _ := r["response"].(map[string]interface{}); resp["username"] != redacted {
+		t.Errorf("response = %v, want the username redacted", r["response"])
+	}
+}
@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+)
+
+const redacted = "[REDACTED]"
+
+// redactor renders messages for the log with sensitive fields masked
+type redactor struct {
+	fields    map[string]bool
+	marshaler jsonpb.Marshaler
+}
+
+func newRedactor(fields []string) *redactor {
+	r := &redactor{
+		fields:    map[string]bool{},
+		marshaler: jsonpb.Marshaler{OrigName: true},
+	}
+	for _, f := range fields {
+		r.fields[f] = true
+	}
+
+	return r
+}
+
+// payload returns m as a JSON-like value with every field named in the
+// redact list replaced, at any depth
+func (r *redactor) payload(m interface{}) interface{} {
+	msg, ok := m.(proto.Message)
+	if !ok || msg == nil {
+		return fmt.Sprintf("%T", m)
+	}
+
+	s, err := r.marshaler.MarshalToString(msg)
+	if err != nil {
+		return fmt.Sprintf("<unprintable %T: %v>", m, err)
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return s
+	}
+
+	return r.redact(v)
+}
+
+func (r *redactor) redact(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if r.fields[k] {
+				t[k] = redacted
+			} else {
+				t[k] = r.redact(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = r.redact(child)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,213 @@
+// Package metrics records Prometheus metrics for gRPC calls and MongoDB
+// commands and serves them over HTTP.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/serhii12/grpc-go/config"
+	"go.mongodb.org/mongo-driver/event"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	unary        = "unary"
+	clientStream = "client_stream"
+	serverStream = "server_stream"
+	bidiStream   = "bidi_stream"
+)
+
+// Metrics holds the collectors of one server
+type Metrics struct {
+	registry *prometheus.Registry
+
+	started  *prometheus.CounterVec
+	handled  *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	received *prometheus.CounterVec
+	sent     *prometheus.CounterVec
+	inFlight *prometheus.GaugeVec
+	panics   *prometheus.CounterVec
+	mongo    *prometheus.HistogramVec
+}
+
+// New registers the gRPC and MongoDB collectors, plus the Go runtime and
+// process ones, on a registry of their own
+func New() *Metrics {
+	rpcLabels := []string{"method", "type"}
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		started: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_started_total",
+			Help: "RPCs started on the server.",
+		}, rpcLabels),
+		handled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_handled_total",
+			Help: "RPCs completed on the server, by status code.",
+		}, append(rpcLabels, "code")),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_handling_seconds",
+			Help:    "Time from the start of an RPC until the server finished handling it.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2.5, 12),
+		}, rpcLabels),
+		received: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_msg_received_total",
+			Help: "Stream messages received from clients.",
+		}, rpcLabels),
+		sent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_msg_sent_total",
+			Help: "Stream messages sent to clients.",
+		}, rpcLabels),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "grpc_server_in_flight",
+			Help: "RPCs currently being handled.",
+		}, rpcLabels),
+		panics: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_panics_total",
+			Help: "Panics recovered in RPC handlers.",
+		}, []string{"method"}),
+		mongo: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "mongo_command_seconds",
+			Help:    "Duration of MongoDB commands, by command and outcome.",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2.5, 12),
+		}, []string{"command", "result"}),
+	}
+
+	m.registry.MustRegister(
+		m.started, m.handled, m.latency, m.received, m.sent, m.inFlight, m.panics, m.mongo,
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// Serve exposes the metrics over HTTP as configured. It returns a nil
+// server when metrics are disabled, and an error when the address cannot
+// be bound, so a port taken by another server is not silently ignored.
+func (m *Metrics) Serve(cfg config.Metrics) (*http.Server, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	lis, err := net.Listen("tcp", cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("metrics: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(cfg.Path, promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	srv := &http.Server{Handler: mux}
+
+	go func() {
+		slog.Info("Metrics endpoint started", "address", lis.Addr().String(), "path", cfg.Path)
+		if err := srv.Serve(lis); err != nil && err != http.ErrServerClosed {
+			slog.Error("Metrics endpoint failed", "error", err)
+		}
+	}()
+
+	return srv, nil
+}
+
+// UnaryServerInterceptor records unary RPCs
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		done := m.begin(info.FullMethod, unary)
+		resp, err := handler(ctx, req)
+		done(err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records streaming RPCs and the messages they carry
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		typ := streamType(info)
+		done := m.begin(info.FullMethod, typ)
+		err := handler(srv, &monitoredStream{
+			ServerStream: ss,
+			received:     m.received.WithLabelValues(info.FullMethod, typ),
+			sent:         m.sent.WithLabelValues(info.FullMethod, typ),
+		})
+		done(err)
+
+		return err
+	}
+}
+
+// begin counts an RPC as started and returns the func recording its end
+func (m *Metrics) begin(method, typ string) func(error) {
+	start := time.Now()
+	m.started.WithLabelValues(method, typ).Inc()
+	inFlight := m.inFlight.WithLabelValues(method, typ)
+	inFlight.Inc()
+
+	return func(err error) {
+		inFlight.Dec()
+		m.handled.WithLabelValues(method, typ, status.Code(err).String()).Inc()
+		m.latency.WithLabelValues(method, typ).Observe(time.Since(start).Seconds())
+	}
+}
+
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return bidiStream
+	case info.IsClientStream:
+		return clientStream
+	default:
+		return serverStream
+	}
+}
+
+// monitoredStream counts the messages passing through a server stream
+type monitoredStream struct {
+	grpc.ServerStream
+	received prometheus.Counter
+	sent     prometheus.Counter
+}
+
+func (s *monitoredStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Inc()
+	}
+
+	return err
+}
+
+func (s *monitoredStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Inc()
+	}
+
+	return err
+}
+
+// Panicked counts a panic recovered in a handler of method
+func (m *Metrics) Panicked(method string) {
+	m.panics.WithLabelValues(method).Inc()
+}
+
+// MongoMonitor returns a command monitor timing every MongoDB command,
+// to be set with options.Client().SetMonitor
+func (m *Metrics) MongoMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			m.mongo.WithLabelValues(e.CommandName, "success").Observe(e.Duration.Seconds())
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			m.mongo.WithLabelValues(e.CommandName, "failure").Observe(e.Duration.Seconds())
+		},
+	}
+}
@@ -0,0 +1,201 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/serhii12/grpc-go/config"
+	"github.com/serhii12/grpc-go/internal/grpctest"
+	"go.mongodb.org/mongo-driver/event"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+)
+
+func startServer(t *testing.T) (*Metrics, *grpctest.Server, testpb.TestServiceClient) {
+	t.Helper()
+	m := New()
+	s := grpctest.NewServer(t,
+		func(s *grpc.Server) { testpb.RegisterTestServiceServer(s, grpctest.TestService{}) },
+		grpc.ChainUnaryInterceptor(m.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(m.StreamServerInterceptor()),
+	)
+
+	return m, s, testpb.NewTestServiceClient(s.Conn)
+}
+
+// histogramCount returns how many observations the histogram of name with
+// the given label values holds
+func histogramCount(t *testing.T, m *Metrics, name string, labels map[string]string) uint64 {
+	t.Helper()
+	families, err := m.registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+		for _, metric := range f.GetMetric() {
+			if hasLabels(metric, labels) {
+				return metric.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+
+	return 0
+}
+
+func hasLabels(metric *dto.Metric, labels map[string]string) bool {
+	matched := 0
+	for _, pair := range metric.GetLabel() {
+		if value, ok := labels[pair.GetName()]; ok && value == pair.GetValue() {
+			matched++
+		}
+	}
+
+	return matched == len(labels)
+}
+
+func TestUnaryCalls(t *testing.T) {
+	m, s, c := startServer(t)
+	const method = testpb.TestService_UnaryCall_FullMethodName
+
+	if _, err := c.UnaryCall(context.Background(), &testpb.SimpleRequest{}); err != nil {
+		t.Fatalf("UnaryCall: %v", err)
+	}
+	c.UnaryCall(context.Background(), &testpb.SimpleRequest{ResponseStatus: &testpb.EchoStatus{Code: int32(codes.NotFound)}})
+	s.Wait(t, method)
+	s.Wait(t, method)
+
+	if got := testutil.ToFloat64(m.started.WithLabelValues(method, unary)); got != 2 {
+		t.Errorf("started = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.handled.WithLabelValues(method, unary, "OK")); got != 1 {
+		t.Errorf("handled OK = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.handled.WithLabelValues(method, unary, "NotFound")); got != 1 {
+		t.Errorf("handled NotFound = %v, want 1", got)
+	}
+	if got := histogramCount(t, m, "grpc_server_handling_seconds", map[string]string{"method": method, "type": unary}); got != 2 {
+		t.Errorf("handling_seconds count = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.inFlight.WithLabelValues(method, unary)); got != 0 {
+		t.Errorf("in_flight = %v after the calls returned", got)
+	}
+}
+
+func TestStreams(t *testing.T) {
+	m, s, c := startServer(t)
+	ctx := context.Background()
+	two := []*testpb.ResponseParameters{{Size: 1}, {Size: 1}}
+
+	out, err := c.StreamingOutputCall(ctx, &testpb.StreamingOutputCallRequest{ResponseParameters: two})
+	if err != nil {
+		t.Fatalf("StreamingOutputCall: %v", err)
+	}
+	drain(t, out.Recv)
+
+	in, err := c.StreamingInputCall(ctx)
+	if err != nil {
+		t.Fatalf("StreamingInputCall: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		in.Send(&testpb.StreamingInputCallRequest{})
+	}
+	if _, err := in.CloseAndRecv(); err != nil {
+		t.Fatalf("CloseAndRecv: %v", err)
+	}
+
+	bidi, err := c.FullDuplexCall(ctx)
+	if err != nil {
+		t.Fatalf("FullDuplexCall: %v", err)
+	}
+	bidi.Send(&testpb.StreamingOutputCallRequest{ResponseParameters: two})
+	bidi.CloseSend()
+	drain(t, bidi.Recv)
+
+	tests := []struct {
+		method, typ    string
+		received, sent float64
+	}{
+		{testpb.TestService_StreamingOutputCall_FullMethodName, serverStream, 1, 2},
+		{testpb.TestService_StreamingInputCall_FullMethodName, clientStream, 3, 1},
+		{testpb.TestService_FullDuplexCall_FullMethodName, bidiStream, 1, 2},
+	}
+	for _, tt := range tests {
+		s.Wait(t, tt.method)
+		if got := testutil.ToFloat64(m.handled.WithLabelValues(tt.method, tt.typ, "OK")); got != 1 {
+			t.Errorf("%s: handled = %v, want 1", tt.typ, got)
+		}
+		if got := testutil.ToFloat64(m.received.WithLabelValues(tt.method, tt.typ)); got != tt.received {
+			t.Errorf("%s: msg_received = %v, want %v", tt.typ, got, tt.received)
+		}
+		if got := testutil.ToFloat64(m.sent.WithLabelValues(tt.method, tt.typ)); got != tt.sent {
+			t.Errorf("%s: msg_sent = %v, want %v", tt.typ, got, tt.sent)
+		}
+		if got := histogramCount(t, m, "grpc_server_handling_seconds", map[string]string{"method": tt.method, "type": tt.typ}); got != 1 {
+			t.Errorf("%s: handling_seconds count = %v, want 1", tt.typ, got)
+		}
+	}
+}
+
+// drain receives until the stream ends
+func drain[T any](t *testing.T, recv func() (T, error)) {
+	t.Helper()
+	for {
+		if _, err := recv(); err == io.EOF {
+			return
+		} else if err != nil {
+			t.Fatalf("Recv: %v", err)
+		}
+	}
+}
+
+func TestPanicsAndMongoCommands(t *testing.T) {
+	m := New()
+	m.Panicked("/a.Service/Method")
+	if got := testutil.ToFloat64(m.panics.WithLabelValues("/a.Service/Method")); got != 1 {
+		t.Errorf("panics = %v, want 1", got)
+	}
+
+	monitor := m.MongoMonitor()
+	finished := event.CommandFinishedEvent{CommandName: "find", Duration: time.Millisecond}
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: finished})
+	monitor.Failed(context.Background(), &event.CommandFailedEvent{CommandFinishedEvent: finished})
+	monitor.Failed(context.Background(), &event.CommandFailedEvent{CommandFinishedEvent: finished})
+
+	if got := histogramCount(t, m, "mongo_command_seconds", map[string]string{"command": "find", "result": "success"}); got != 1 {
+		t.Errorf("successful finds = %v, want 1", got)
+	}
+	if got := histogramCount(t, m, "mongo_command_seconds", map[string]string{"command": "find", "result": "failure"}); got != 2 {
+		t.Errorf("failed finds = %v, want 2", got)
+	}
+}
+
+func TestServe(t *testing.T) {
+	m := New()
+	if srv, err := m.Serve(config.DefaultMetrics()); srv != nil || err != nil {
+		t.Errorf("Serve with the defaults = %v, %v, want metrics off", srv, err)
+	}
+
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer busy.Close()
+
+	cfg := config.DefaultMetrics()
+	cfg.Enabled = true
+	cfg.Address = busy.Addr().String()
+	if srv, err := m.Serve(cfg); err == nil {
+		srv.Close()
+		t.Error("Serve on a busy address succeeded")
+	}
+}
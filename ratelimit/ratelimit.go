@@ -0,0 +1,222 @@
+// Package ratelimit keeps one client from saturating a server: every client
+// gets a token bucket per method and a cap on its concurrent streams.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/serhii12/grpc-go/certs"
+	"github.com/serhii12/grpc-go/config"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// sweepInterval is how often buckets that have refilled are dropped
+const sweepInterval = time.Minute
+
+// Limiter holds the buckets and stream counts of every client
+type Limiter struct {
+	cfg config.RateLimit
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	streams   map[string]int
+	lastSweep time.Time
+}
+
+type bucketKey struct {
+	method string
+	client string
+}
+
+// bucket is a token bucket, refilled lazily when tokens are taken
+type bucket struct {
+	tokens float64
+	last   time.Time
+	limit  config.MethodLimit
+}
+
+// take removes a token, or reports how long until one is available
+func (b *bucket) take(now time.Time) (time.Duration, bool) {
+	b.tokens = math.Min(float64(b.limit.Burst), b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+
+	return time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second)), false
+}
+
+// full reports whether the bucket has refilled, so dropping it loses nothing
+func (b *bucket) full(now time.Time) bool {
+	return b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate >= float64(b.limit.Burst)
+}
+
+// New returns a limiter enforcing cfg
+func New(cfg config.RateLimit) *Limiter {
+	return &Limiter{
+		cfg:       cfg,
+		buckets:   map[bucketKey]*bucket{},
+		streams:   map[string]int{},
+		lastSweep: time.Now(),
+	}
+}
+
+// UnaryServerInterceptor rejects unary RPCs over the client's rate
+// with codes.ResourceExhausted
+func (l *Limiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !l.cfg.Enabled {
+			return handler(ctx, req)
+		}
+
+		if err := l.allow(info.FullMethod, client(ctx)); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects streams over the client's rate or
+// beyond its concurrent stream cap with codes.ResourceExhausted
+func (l *Limiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !l.cfg.Enabled {
+			return handler(srv, ss)
+		}
+
+		c := client(ss.Context())
+		if err := l.allow(info.FullMethod, c); err != nil {
+			return err
+		}
+
+		if err := l.openStream(c); err != nil {
+			return err
+		}
+		defer l.closeStream(c)
+
+		return handler(srv, ss)
+	}
+}
+
+func (l *Limiter) allow(method, client string) error {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	k := bucketKey{method: method, client: client}
+	b, ok := l.buckets[k]
+	if !ok {
+		limit := l.limit(method)
+		b = &bucket{tokens: float64(limit.Burst), last: now, limit: limit}
+		l.buckets[k] = b
+	}
+
+	if wait, ok := b.take(now); !ok {
+		return exhausted(fmt.Sprintf("Rate limit of %g calls per second exceeded for %s", b.limit.Rate, method), wait)
+	}
+
+	return nil
+}
+
+func (l *Limiter) limit(method string) config.MethodLimit {
+	if limit, ok := l.cfg.Methods[method]; ok {
+		return limit
+	}
+
+	return config.MethodLimit{Rate: l.cfg.Rate, Burst: l.cfg.Burst}
+}
+
+// sweep drops full buckets so clients that went away do not pile up
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for k, b := range l.buckets {
+		if b.full(now) {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+func (l *Limiter) openStream(client string) error {
+	if l.cfg.MaxStreams == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.streams[client] >= l.cfg.MaxStreams {
+		// a slot frees up when one of the client's streams ends, which
+		// cannot be predicted, so no retry delay is suggested
+		return exhausted(fmt.Sprintf("Limit of %d concurrent streams reached", l.cfg.MaxStreams), 0)
+	}
+	l.streams[client]++
+
+	return nil
+}
+
+func (l *Limiter) closeStream(client string) {
+	if l.cfg.MaxStreams == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.streams[client]--; l.streams[client] <= 0 {
+		delete(l.streams, client)
+	}
+}
+
+// client identifies the caller by its certificate principal,
+// or by its IP address without one
+func client(ctx context.Context) string {
+	if principal, ok := certs.PrincipalFromContext(ctx); ok {
+		return principal
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+
+	return p.Addr.String()
+}
+
+// exhausted returns a ResourceExhausted status telling the client, when
+// retry is positive, how long to wait before trying again
+func exhausted(msg string, retry time.Duration) error {
+	st := status.New(codes.ResourceExhausted, msg)
+	if retry <= 0 {
+		return st.Err()
+	}
+
+	withRetry, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retry)})
+	if err != nil {
+		return st.Err()
+	}
+
+	return withRetry.Err()
+}
@@ -0,0 +1,169 @@
+package ratelimit
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/serhii12/grpc-go/config"
+	"github.com/serhii12/grpc-go/internal/grpctest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/status"
+)
+
+func startServer(t *testing.T, cfg config.RateLimit) (testpb.TestServiceClient, *grpctest.Server) {
+	t.Helper()
+	l := New(cfg)
+	s := grpctest.NewServer(t,
+		func(s *grpc.Server) { testpb.RegisterTestServiceServer(s, grpctest.TestService{}) },
+		grpc.ChainUnaryInterceptor(l.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(l.StreamServerInterceptor()),
+	)
+	return testpb.NewTestServiceClient(s.Conn), s
+}
+
+// retryDelay returns the delay suggested by err's RetryInfo, if it has one
+func retryDelay(err error) (time.Duration, bool) {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+func TestRateLimit(t *testing.T) {
+	c, _ := startServer(t, config.RateLimit{
+		Enabled: true,
+		Rate:    1,
+		Burst:   3,
+		Methods: map[string]config.MethodLimit{
+			testpb.TestService_UnaryCall_FullMethodName: {Rate: 1, Burst: 2},
+		},
+	})
+	ctx := context.Background()
+
+	for i := 1; i <= 2; i++ {
+		if _, err := c.UnaryCall(ctx, &testpb.SimpleRequest{}); err != nil {
+			t.Fatalf("call %d within the burst: %v", i, err)
+		}
+	}
+
+	_, err := c.UnaryCall(ctx, &testpb.SimpleRequest{})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("call over the burst: got %v, want ResourceExhausted", err)
+	}
+	delay, ok := retryDelay(err)
+	if !ok {
+		t.Fatal("no RetryInfo on the rejection")
+	}
+	if delay <= 0 || delay > time.Second {
+		t.Errorf("retry delay = %v, want at most the second a token takes", delay)
+	}
+
+	// other methods have buckets of their own with the default limit
+	for i := 1; i <= 3; i++ {
+		stream, err := c.StreamingOutputCall(ctx, &testpb.StreamingOutputCallRequest{})
+		if err != nil {
+			t.Fatalf("StreamingOutputCall: %v", err)
+		}
+		if _, err := stream.Recv(); err != io.EOF {
+			t.Fatalf("stream %d within the default burst: %v", i, err)
+		}
+	}
+	stream, err := c.StreamingOutputCall(ctx, &testpb.StreamingOutputCallRequest{})
+	if err != nil {
+		t.Fatalf("StreamingOutputCall: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("stream over the default burst: got %v, want ResourceExhausted", err)
+	}
+
+	time.Sleep(delay)
+	if _, err := c.UnaryCall(ctx, &testpb.SimpleRequest{}); err != nil {
+		t.Errorf("call after the retry delay: %v", err)
+	}
+}
+
+func TestStreamCap(t *testing.T) {
+	c, s := startServer(t, config.RateLimit{Enabled: true, Rate: 100, Burst: 100, MaxStreams: 2})
+	ctx := context.Background()
+
+	open := func() (testpb.TestService_FullDuplexCallClient, error) {
+		stream, err := c.FullDuplexCall(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req := &testpb.StreamingOutputCallRequest{ResponseParameters: []*testpb.ResponseParameters{{Size: 1}}}
+		if err := stream.Send(req); err != nil {
+			return nil, err
+		}
+		_, err = stream.Recv()
+		return stream, err
+	}
+
+	var streams []testpb.TestService_FullDuplexCallClient
+	for i := 1; i <= 2; i++ {
+		stream, err := open()
+		if err != nil {
+			t.Fatalf("stream %d within the cap: %v", i, err)
+		}
+		streams = append(streams, stream)
+	}
+
+	_, err := open()
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("stream over the cap: got %v, want ResourceExhausted", err)
+	}
+	if _, ok := retryDelay(err); ok {
+		t.Error("the stream cap suggested a retry delay")
+	}
+	// the rejected stream never held a slot
+	s.Wait(t, testpb.TestService_FullDuplexCall_FullMethodName)
+
+	// unary calls are not streams and are not capped
+	if _, err := c.UnaryCall(ctx, &testpb.SimpleRequest{}); err != nil {
+		t.Errorf("UnaryCall at the stream cap: %v", err)
+	}
+
+	if err := streams[0].CloseSend(); err != nil {
+		t.Fatalf("CloseSend: %v", err)
+	}
+	if _, err := streams[0].Recv(); err != io.EOF {
+		t.Fatalf("Recv after CloseSend: %v", err)
+	}
+	s.Wait(t, testpb.TestService_FullDuplexCall_FullMethodName)
+
+	if _, err := open(); err != nil {
+		t.Errorf("stream after one ended: %v", err)
+	}
+}
+
+func TestClientsHaveBucketsOfTheirOwn(t *testing.T) {
+	l := New(config.RateLimit{Enabled: true, Rate: 1, Burst: 1})
+	const method = "/test.Service/Method"
+
+	if err := l.allow(method, "ann"); err != nil {
+		t.Fatalf("ann's first call: %v", err)
+	}
+	if err := l.allow(method, "ann"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("ann's second call: got %v, want ResourceExhausted", err)
+	}
+	if err := l.allow(method, "bob"); err != nil {
+		t.Errorf("bob's first call: %v", err)
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	c, _ := startServer(t, config.RateLimit{Rate: 1, Burst: 1, MaxStreams: 1})
+
+	for i := 1; i <= 3; i++ {
+		if _, err := c.UnaryCall(context.Background(), &testpb.SimpleRequest{}); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+}
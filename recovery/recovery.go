@@ -0,0 +1,64 @@
+// Package recovery turns a panicking handler into a failed RPC instead of a
+// crashed server.
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
+	"github.com/serhii12/grpc-go/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PanicCounter counts recovered panics per method
+type PanicCounter interface {
+	Panicked(method string)
+}
+
+// UnaryServerInterceptor recovers panics in unary handlers, logs them with
+// their stack trace and request ID and fails the call with codes.Internal.
+// It should be the last interceptor in the chain so the others see the error.
+func UnaryServerInterceptor(counter PanicCounter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ctx, info.FullMethod, p, counter)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor
+func StreamServerInterceptor(counter PanicCounter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				err = recovered(ss.Context(), info.FullMethod, p, counter)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+func recovered(ctx context.Context, method string, p interface{}, counter PanicCounter) error {
+	slog.ErrorContext(ctx, "Recovered from a panic in a handler",
+		"method", method,
+		"request_id", requestid.FromContext(ctx),
+		"panic", fmt.Sprint(p),
+		"stack", string(debug.Stack()),
+	)
+
+	if counter != nil {
+		counter.Panicked(method)
+	}
+
+	// the panic value may hold internals, so clients only learn that it happened
+	return status.Errorf(codes.Internal, "internal error while handling %s", method)
+}
@@ -0,0 +1,114 @@
+package recovery
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/serhii12/grpc-go/internal/grpctest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/status"
+)
+
+// panickingService panics in calls asking for a username
+type panickingService struct {
+	grpctest.TestService
+}
+
+func (s panickingService) UnaryCall(ctx context.Context, req *testpb.SimpleRequest) (*testpb.SimpleResponse, error) {
+	if req.GetFillUsername() {
+		panic("secret internals")
+	}
+	return s.TestService.UnaryCall(ctx, req)
+}
+
+func (panickingService) StreamingOutputCall(req *testpb.StreamingOutputCallRequest, stream testpb.TestService_StreamingOutputCallServer) error {
+	if err := stream.Send(&testpb.StreamingOutputCallResponse{}); err != nil {
+		return err
+	}
+	var m map[string]int
+	m["nil map"]++
+	return nil
+}
+
+type counter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (c *counter) Panicked(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[method]++
+}
+
+func (c *counter) count(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[method]
+}
+
+func TestPanicsFailTheCallOnly(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	panics := &counter{counts: make(map[string]int)}
+	s := grpctest.NewServer(t,
+		func(s *grpc.Server) { testpb.RegisterTestServiceServer(s, panickingService{}) },
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(panics)),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor(panics)),
+	)
+	c := testpb.NewTestServiceClient(s.Conn)
+	ctx := context.Background()
+
+	for i := 1; i <= 2; i++ {
+		_, err := c.UnaryCall(ctx, &testpb.SimpleRequest{FillUsername: true})
+		if status.Code(err) != codes.Internal {
+			t.Fatalf("UnaryCall: got %v, want Internal", err)
+		}
+		if msg := status.Convert(err).Message(); msg != "internal error while handling "+testpb.TestService_UnaryCall_FullMethodName {
+			t.Errorf("message %q gives the panic away", msg)
+		}
+		if got := panics.count(testpb.TestService_UnaryCall_FullMethodName); got != i {
+			t.Errorf("counted %d unary panics, want %d", got, i)
+		}
+	}
+
+	stream, err := c.StreamingOutputCall(ctx, &testpb.StreamingOutputCallRequest{})
+	if err != nil {
+		t.Fatalf("StreamingOutputCall: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv before the panic: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Internal {
+		t.Fatalf("Recv: got %v, want Internal", err)
+	}
+	if got := panics.count(testpb.TestService_StreamingOutputCall_FullMethodName); got != 1 {
+		t.Errorf("counted %d stream panics, want 1", got)
+	}
+
+	// the server is still serving on the same connection
+	if _, err := c.UnaryCall(ctx, &testpb.SimpleRequest{}); err != nil {
+		t.Errorf("UnaryCall after the panics: %v", err)
+	}
+}
+
+func TestNilCounter(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	s := grpctest.NewServer(t,
+		func(s *grpc.Server) { testpb.RegisterTestServiceServer(s, panickingService{}) },
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(nil)),
+	)
+
+	_, err := testpb.NewTestServiceClient(s.Conn).UnaryCall(context.Background(), &testpb.SimpleRequest{FillUsername: true})
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("got %v, want Internal", err)
+	}
+}
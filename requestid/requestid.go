@@ -0,0 +1,161 @@
+// Package requestid gives every RPC an ID, carried in the x-request-id
+// metadata, so a client error can be matched with the server's logs.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataKey is the request and response metadata key carrying the ID
+const MetadataKey = "x-request-id"
+
+// maxLength bounds the IDs accepted from clients
+const maxLength = 128
+
+type contextKey struct{}
+
+// New returns a random version 4 UUID
+func New() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("requestid: %v", err))
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// NewContext returns a copy of ctx carrying id
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID stored in ctx, or "" if there is none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// incoming returns the ID sent by the client, or a new one when it sent
+// none or an unusable one
+func incoming(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(MetadataKey); len(ids) > 0 && valid(ids[0]) {
+			return ids[0]
+		}
+	}
+
+	return New()
+}
+
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// withDetails attaches the ID to a failed RPC's status as RequestInfo
+func withDetails(err error, id string) error {
+	if err == nil {
+		return nil
+	}
+
+	st := status.Convert(err)
+	withInfo, detailErr := st.WithDetails(&errdetails.RequestInfo{RequestId: id})
+	if detailErr != nil {
+		return err
+	}
+
+	return withInfo.Err()
+}
+
+// UnaryServerInterceptor stores the request ID in the context, echoes it in
+// the response header and trailer and adds it to error details.
+// It must run before interceptors that log the ID.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id := incoming(ctx)
+		md := metadata.Pairs(MetadataKey, id)
+		grpc.SetHeader(ctx, md)
+		grpc.SetTrailer(ctx, md)
+
+		resp, err := handler(NewContext(ctx, id), req)
+		return resp, withDetails(err, id)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of UnaryServerInterceptor
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := incoming(ss.Context())
+		md := metadata.Pairs(MetadataKey, id)
+		ss.SetHeader(md)
+		ss.SetTrailer(md)
+
+		err := handler(srv, &stream{ServerStream: ss, ctx: NewContext(ss.Context(), id)})
+		return withDetails(err, id)
+	}
+}
+
+// stream overrides the context of a server stream
+type stream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *stream) Context() context.Context {
+	return s.ctx
+}
+
+// outgoing returns ctx with a request ID in its outgoing metadata,
+// keeping one the caller already set
+func outgoing(ctx context.Context) (context.Context, string) {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		if ids := md.Get(MetadataKey); len(ids) > 0 {
+			return ctx, ids[0]
+		}
+	}
+
+	id := New()
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, id), id
+}
+
+// UnaryClientInterceptor sends a request ID with every call and reports it
+// to onID, if not nil, so the client can print it
+func UnaryClientInterceptor(onID func(method, id string)) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, id := outgoing(ctx)
+		if onID != nil {
+			onID(method, id)
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor is the streaming counterpart of UnaryClientInterceptor
+func StreamClientInterceptor(onID func(method, id string)) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, id := outgoing(ctx)
+		if onID != nil {
+			onID(method, id)
+		}
+
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
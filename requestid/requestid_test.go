@@ -0,0 +1,227 @@
+package requestid
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/serhii12/grpc-go/internal/grpctest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var uuidV4 = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNew(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id := New()
+		if !uuidV4.MatchString(id) {
+			t.Fatalf("%q is not a version 4 UUID", id)
+		}
+		if seen[id] {
+			t.Fatalf("%q generated twice", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestValid(t *testing.T) {
+	// gRPC clients refuse to send some of these, other clients may not
+	for _, id := range []string{"", "req 42", "req\t42", "req\x0142", "réq-42", strings.Repeat("a", maxLength+1)} {
+		if valid(id) {
+			t.Errorf("valid(%q) = true", id)
+		}
+	}
+	for _, id := range []string{"a", "req-42_abc", "!~", New(), strings.Repeat("a", maxLength)} {
+		if !valid(id) {
+			t.Errorf("valid(%q) = false", id)
+		}
+	}
+}
+
+// handlerIDs records the request ID each handler sees in its context
+type handlerIDs struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (h *handlerIDs) last() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.ids[len(h.ids)-1]
+}
+
+func (h *handlerIDs) add(ctx context.Context) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ids = append(h.ids, FromContext(ctx))
+}
+
+func startServer(t *testing.T) (*grpctest.Server, testpb.TestServiceClient, *handlerIDs) {
+	t.Helper()
+	seen := &handlerIDs{}
+	s := grpctest.NewServer(t,
+		func(s *grpc.Server) { testpb.RegisterTestServiceServer(s, grpctest.TestService{}) },
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(),
+			func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				seen.add(ctx)
+				return handler(ctx, req)
+			}),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor(),
+			func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				seen.add(ss.Context())
+				return handler(srv, ss)
+			}),
+	)
+
+	return s, testpb.NewTestServiceClient(s.Conn), seen
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tests := []struct {
+		name string
+		sent []string
+		// keep is whether the server keeps the sent ID or replaces it
+		keep bool
+	}{
+		{name: "none sent"},
+		{name: "well formed", sent: []string{"req-42_abc"}, keep: true},
+		{name: "longest allowed", sent: []string{strings.Repeat("a", maxLength)}, keep: true},
+		{name: "too long", sent: []string{strings.Repeat("a", maxLength+1)}},
+		{name: "empty", sent: []string{""}},
+		{name: "space", sent: []string{"req 42"}},
+		{name: "first of several", sent: []string{"first", "second"}, keep: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, c, seen := startServer(t)
+			ctx := context.Background()
+			for _, id := range tt.sent {
+				ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, id)
+			}
+
+			var header, trailer metadata.MD
+			if _, err := c.UnaryCall(ctx, &testpb.SimpleRequest{}, grpc.Header(&header), grpc.Trailer(&trailer)); err != nil {
+				t.Fatalf("UnaryCall: %v", err)
+			}
+
+			got := seen.last()
+			if tt.keep && got != tt.sent[0] {
+				t.Errorf("handler saw %q, want %q", got, tt.sent[0])
+			}
+			if !tt.keep && !uuidV4.MatchString(got) {
+				t.Errorf("handler saw %q, want a generated ID", got)
+			}
+			if ids := header.Get(MetadataKey); len(ids) != 1 || ids[0] != got {
+				t.Errorf("header = %q, want [%q]", ids, got)
+			}
+			if ids := trailer.Get(MetadataKey); len(ids) != 1 || ids[0] != got {
+				t.Errorf("trailer = %q, want [%q]", ids, got)
+			}
+		})
+	}
+}
+
+// requestInfo returns the request ID in the details of err
+func requestInfo(err error) string {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.RequestInfo); ok {
+			return info.GetRequestId()
+		}
+	}
+
+	return ""
+}
+
+func TestErrorsCarryTheID(t *testing.T) {
+	_, c, _ := startServer(t)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), MetadataKey, "req-7")
+	notFound := &testpb.EchoStatus{Code: int32(codes.NotFound), Message: "no such thing"}
+
+	_, err := c.UnaryCall(ctx, &testpb.SimpleRequest{ResponseStatus: notFound})
+	if status.Code(err) != codes.NotFound || status.Convert(err).Message() != "no such thing" {
+		t.Fatalf("got %v, want the handler's NotFound", err)
+	}
+	if got := requestInfo(err); got != "req-7" {
+		t.Errorf("unary error details carry %q, want req-7", got)
+	}
+
+	stream, err := c.StreamingOutputCall(ctx, &testpb.StreamingOutputCallRequest{ResponseStatus: notFound})
+	if err != nil {
+		t.Fatalf("StreamingOutputCall: %v", err)
+	}
+	_, err = stream.Recv()
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("got %v, want NotFound", err)
+	}
+	if got := requestInfo(err); got != "req-7" {
+		t.Errorf("stream error details carry %q, want req-7", got)
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	_, c, seen := startServer(t)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), MetadataKey, "req-8")
+
+	stream, err := c.FullDuplexCall(ctx)
+	if err != nil {
+		t.Fatalf("FullDuplexCall: %v", err)
+	}
+	stream.CloseSend()
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("Recv: %v", err)
+	}
+
+	if got := seen.last(); got != "req-8" {
+		t.Errorf("handler saw %q, want req-8", got)
+	}
+	header, err := stream.Header()
+	if err != nil {
+		t.Fatalf("Header: %v", err)
+	}
+	if ids := header.Get(MetadataKey); len(ids) != 1 || ids[0] != "req-8" {
+		t.Errorf("header = %q, want [req-8]", ids)
+	}
+	if ids := stream.Trailer().Get(MetadataKey); len(ids) != 1 || ids[0] != "req-8" {
+		t.Errorf("trailer = %q, want [req-8]", ids)
+	}
+}
+
+func TestClientInterceptors(t *testing.T) {
+	s, _, seen := startServer(t)
+	var reported []string
+	onID := func(method, id string) { reported = append(reported, id) }
+	c := testpb.NewTestServiceClient(s.Dial(t,
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor(onID)),
+		grpc.WithChainStreamInterceptor(StreamClientInterceptor(onID)),
+	))
+
+	if _, err := c.UnaryCall(context.Background(), &testpb.SimpleRequest{}); err != nil {
+		t.Fatalf("UnaryCall: %v", err)
+	}
+	if !uuidV4.MatchString(reported[0]) || seen.last() != reported[0] {
+		t.Errorf("client reported %q, server saw %q", reported[0], seen.last())
+	}
+
+	// an ID the caller set is sent as is
+	ctx := metadata.AppendToOutgoingContext(context.Background(), MetadataKey, "mine")
+	stream, err := c.StreamingOutputCall(ctx, &testpb.StreamingOutputCallRequest{})
+	if err != nil {
+		t.Fatalf("StreamingOutputCall: %v", err)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("Recv: %v", err)
+	}
+	if reported[1] != "mine" || seen.last() != "mine" {
+		t.Errorf("client reported %q, server saw %q, want mine", reported[1], seen.last())
+	}
+}
@@ -0,0 +1,49 @@
+// Package shutdown stops gRPC servers without cutting off in-flight RPCs.
+package shutdown
+
+import (
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+)
+
+// Wait blocks until the process receives SIGINT or SIGTERM
+func Wait() os.Signal {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(ch)
+
+	return <-ch
+}
+
+// Graceful reports every service of hs as NOT_SERVING so clients stop
+// sending work, then lets in-flight RPCs finish for at most timeout before
+// forcing the remaining ones closed. It returns once s has fully stopped.
+func Graceful(s *grpc.Server, hs *health.Server, timeout time.Duration) {
+	if hs != nil {
+		hs.Shutdown()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		slog.Info("All RPCs finished")
+	case <-timer.C:
+		slog.Warn("RPCs still running after the shutdown timeout, stopping them", "timeout", timeout.String())
+		s.Stop()
+		<-done
+	}
+}
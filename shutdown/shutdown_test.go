@@ -0,0 +1,145 @@
+package shutdown
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/serhii12/grpc-go/internal/grpctest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	testpb "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/status"
+)
+
+const streamMethod = "/grpc.testing.TestService/StreamingOutputCall"
+
+// blockingService sends one response, waits for release and then sends
+// the rest of the requested ones
+type blockingService struct {
+	testpb.UnimplementedTestServiceServer
+	release chan struct{}
+}
+
+func (s *blockingService) StreamingOutputCall(req *testpb.StreamingOutputCallRequest, stream testpb.TestService_StreamingOutputCallServer) error {
+	for i := range req.GetResponseParameters() {
+		if err := stream.Send(&testpb.StreamingOutputCallResponse{}); err != nil {
+			return err
+		}
+		if i > 0 {
+			continue
+		}
+
+		select {
+		case <-s.release:
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+
+	return nil
+}
+
+// startStream starts a server with the health service and opens a stream
+// of n responses, returning once the first one arrived
+func startStream(t *testing.T, n int) (*grpctest.Server, *health.Server, *blockingService, testpb.TestService_StreamingOutputCallClient) {
+	t.Helper()
+	svc := &blockingService{release: make(chan struct{})}
+	hs := health.NewServer()
+	hs.SetServingStatus("grpc.testing.TestService", healthpb.HealthCheckResponse_SERVING)
+	s := grpctest.NewServer(t, func(s *grpc.Server) {
+		testpb.RegisterTestServiceServer(s, svc)
+		healthpb.RegisterHealthServer(s, hs)
+	})
+
+	req := &testpb.StreamingOutputCallRequest{ResponseParameters: make([]*testpb.ResponseParameters, n)}
+	stream, err := testpb.NewTestServiceClient(s.Conn).StreamingOutputCall(context.Background(), req)
+	if err != nil {
+		t.Fatalf("StreamingOutputCall: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+
+	return s, hs, svc, stream
+}
+
+// waitNotServing polls until every service of hs is NOT_SERVING
+func waitNotServing(t *testing.T, hs *health.Server, services ...string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for _, service := range services {
+		for {
+			resp, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+			if err == nil && resp.GetStatus() == healthpb.HealthCheckResponse_NOT_SERVING {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("%q is still %v", service, resp.GetStatus())
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+}
+
+func TestGracefulDrainsInFlightStreams(t *testing.T) {
+	s, hs, svc, stream := startStream(t, 3)
+
+	stopped := make(chan struct{})
+	go func() {
+		Graceful(s.Server, hs, 5*time.Second)
+		close(stopped)
+	}()
+
+	waitNotServing(t, hs, "", "grpc.testing.TestService")
+	select {
+	case <-stopped:
+		t.Fatal("Graceful returned while a stream was still running")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(svc.release)
+	received := 1
+	for {
+		_, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv during the drain: %v", err)
+		}
+		received++
+	}
+	if received != 3 {
+		t.Errorf("received %d responses, want 3", received)
+	}
+	if h := s.Wait(t, streamMethod); h.Code != codes.OK {
+		t.Errorf("handler returned %v, want OK", h.Err)
+	}
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Graceful did not return once the stream finished")
+	}
+}
+
+func TestGracefulStopsStreamsAfterTimeout(t *testing.T) {
+	s, hs, _, stream := startStream(t, 2)
+
+	start := time.Now()
+	Graceful(s.Server, hs, 100*time.Millisecond)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Graceful took %v with a 100ms timeout", elapsed)
+	}
+
+	if h := s.Wait(t, streamMethod); h.Code != codes.Canceled {
+		t.Errorf("handler returned %v, want Canceled", h.Err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("Recv after the forced stop: got %v, want Unavailable", err)
+	}
+}
@@ -0,0 +1,63 @@
+// Command gencerts writes the same files as instructions.sh, plus a client
+// certificate for mutual TLS, without needing openssl.
+//
+//	go run ./ssl/gencerts -out ssl -host localhost
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/serhii12/grpc-go/certs"
+)
+
+func main() {
+	out := flag.String("out", "ssl", "directory the certificates are written to")
+	hosts := flag.String("host", "localhost,127.0.0.1", "comma separated host names and IPs of the server")
+	client := flag.String("client", "client", "common name of the client certificate, used as its principal")
+	caName := flag.String("ca-name", "grpc-playground CA", "common name of the CA, distinct from the server's so chains read clearly")
+	validFor := flag.Duration("valid-for", 3650*24*time.Hour, "validity of the certificates")
+	flag.Parse()
+
+	hostList := strings.Split(*hosts, ",")
+
+	ca, err := certs.NewAuthority(*caName, *validFor)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	server, err := ca.IssueServer(hostList[0], hostList, *validFor)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	clientPair, err := ca.IssueClient(*client, *validFor)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	files := []struct {
+		name string
+		data []byte
+		perm os.FileMode
+	}{
+		{"ca.crt", ca.CertPEM, 0644},
+		{"ca.key", ca.KeyPEM, 0600},
+		{"server.crt", server.CertPEM, 0644},
+		{"server.pem", server.KeyPEM, 0600},
+		{"client.crt", clientPair.CertPEM, 0644},
+		{"client.pem", clientPair.KeyPEM, 0600},
+	}
+
+	for _, f := range files {
+		path := filepath.Join(*out, f.name)
+		if err := os.WriteFile(path, f.data, f.perm); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Wrote %s", path)
+	}
+}
@@ -13,12 +13,16 @@
 # Private files: ca.key, server.key, server.pem, server.crt
 # "Share" files: ca.crt (needed by the client), server.csr (needed by the CA)
 
+# Without openssl, `go run ./ssl/gencerts -out ssl` writes the same files (unencrypted keys)
+# plus client.crt/client.pem for mutual TLS.
+
 # Changes these CN's to match your hosts in your environment if needed.
 SERVER_CN=localhost
+CA_CN="grpc-playground CA"
 
 # Step 1: Generate Certificate Authority + Trust Certificate (ca.crt)
 openssl genrsa -passout pass:1111 -des3 -out ca.key 4096
-openssl req -passin pass:1111 -new -x509 -days 3650 -key ca.key -out ca.crt -subj "/CN=${SERVER_CN}"
+openssl req -passin pass:1111 -new -x509 -days 3650 -key ca.key -out ca.crt -subj "/CN=${CA_CN}"
 
 # Step 2: Generate the Server Private Key (server.key)
 openssl genrsa -passout pass:1111 -des3 -out server.key 4096
@@ -0,0 +1,25 @@
+// Package streamerr turns the errors of stream handlers' Recv and Send
+// calls into the statuses the handlers return.
+package streamerr
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Status turns a failed Recv or Send into the status a stream handler
+// returns. A client that cancelled or ran out of time ended the stream
+// itself, so that status is passed through as a normal termination.
+func Status(err error, action string) error {
+	switch status.Code(err) {
+	case codes.Canceled, codes.DeadlineExceeded:
+		return err
+	}
+
+	return status.Errorf(
+		codes.Internal,
+		fmt.Sprintf("Failed to %s: %v", action, err),
+	)
+}
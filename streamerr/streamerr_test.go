@@ -0,0 +1,29 @@
+package streamerr
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+		msg  string
+	}{
+		{err: status.Error(codes.Canceled, "context canceled"), want: codes.Canceled, msg: "context canceled"},
+		{err: status.Error(codes.DeadlineExceeded, "too late"), want: codes.DeadlineExceeded, msg: "too late"},
+		{err: status.Error(codes.Unavailable, "transport is closing"), want: codes.Internal, msg: "Failed to send data: rpc error: code = Unavailable desc = transport is closing"},
+		{err: errors.New("broken pipe"), want: codes.Internal, msg: "Failed to send data: broken pipe"},
+	}
+
+	for _, tt := range tests {
+		st := status.Convert(Status(tt.err, "send data"))
+		if st.Code() != tt.want || st.Message() != tt.msg {
+			t.Errorf("Status(%v) = %v %q, want %v %q", tt.err, st.Code(), st.Message(), tt.want, tt.msg)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Package tracing sets up OpenTelemetry for the servers and clients: spans
+// for every RPC and MongoDB command, exported to stdout or an OTLP
+// collector, with the W3C trace context carried in gRPC metadata.
+package tracing
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/serhii12/grpc-go/config"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/contrib/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"google.golang.org/grpc"
+)
+
+// Setup installs the global tracer provider and the W3C trace context and
+// baggage propagators. The returned func flushes pending spans and must be
+// called before exiting. When tracing is disabled nothing is installed and
+// the returned func does nothing.
+func Setup(ctx context.Context, service string, cfg config.Tracing) (func(context.Context) error, error) {
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "stdout":
+		exporter, err = stdouttrace.New()
+	case "otlp":
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		err = fmt.Errorf("unknown exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tracing: %v", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(service))),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return tp.Shutdown, nil
+}
+
+// ServerOption traces every RPC a server handles, continuing the trace
+// found in the incoming metadata
+func ServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler())
+}
+
+// DialOption traces every RPC a client makes and propagates the trace
+// in the outgoing metadata
+func DialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler())
+}
+
+// MongoMonitor returns a command monitor recording a span per MongoDB
+// command, as a child of the span in the command's context
+func MongoMonitor() *event.CommandMonitor {
+	return otelmongo.NewMonitor()
+}
+
+// RegisterFlags binds the tracing settings of a client to flags in fs
+func RegisterFlags(fs *flag.FlagSet, cfg *config.Tracing) {
+	*cfg = config.DefaultTracing()
+	fs.BoolVar(&cfg.Enabled, "tracing", cfg.Enabled, "record and export OpenTelemetry spans")
+	fs.StringVar(&cfg.Exporter, "tracing-exporter", cfg.Exporter, "where spans go: stdout or otlp")
+	fs.StringVar(&cfg.Endpoint, "tracing-endpoint", cfg.Endpoint, "host:port of the OTLP gRPC collector")
+}